@@ -0,0 +1,350 @@
+package dennis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newServer(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	c := NewClient(srv.URL, "user", "pass")
+	c.Tokens = TokenStore{}
+	return c
+}
+
+func TestClient_Login(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		wantToken string
+		wantErr   bool
+	}{
+		{name: "success", status: 200, wantToken: "token"},
+		{name: "unauthorized", status: 401, wantErr: true},
+		{name: "server error", status: 500, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+				req := LoginRequest{}
+				b, _ := ioutil.ReadAll(r.Body)
+				_ = json.Unmarshal(b, &req)
+				if r.URL.Path != "/login" || req.Username != "user" || req.Password != "pass" {
+					t.Errorf("unexpected login request %s %+v", r.URL.Path, req)
+				}
+				if r.Header.Get("x-org") != org {
+					t.Errorf("x-org = %q", r.Header.Get("x-org"))
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{"token":"token","ttl":60}`))
+			})
+
+			_, err := c.Login()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Login() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if c.Token() != tt.wantToken {
+				t.Errorf("Token() = %q, want %q", c.Token(), tt.wantToken)
+			}
+		})
+	}
+}
+
+func TestClient_ListFormulas(t *testing.T) {
+	c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-authorization") != "token" {
+			t.Errorf("x-authorization = %q", r.Header.Get("x-authorization"))
+		}
+		_, _ = w.Write([]byte(`{
+			"contexts": [{"name": "DEV"}, {"name": "QA"}],
+			"formulas": [{"command": "rit aws list bucket", "inputs": [{"name": "region", "type": "text"}]}]
+		}`))
+	})
+	c.token = "token"
+
+	got, err := c.ListFormulas()
+	if err != nil {
+		t.Fatalf("ListFormulas() error = %v", err)
+	}
+	if names := got.Contexts.Names(); len(names) != 2 || names[1] != "QA" {
+		t.Errorf("Contexts.Names() = %v", names)
+	}
+	form, ok := got.Formulas.Find("rit aws list bucket")
+	if !ok || len(form.Inputs) != 1 || form.Inputs[0].Name != "region" {
+		t.Errorf("Formulas.Find() = %+v, %v", form, ok)
+	}
+}
+
+func TestClient_SendCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{name: "created", status: 201},
+		{name: "forbidden", status: 403, wantErr: true},
+		{name: "server error", status: 500, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+				cmd := CommandRequest{}
+				b, _ := ioutil.ReadAll(r.Body)
+				_ = json.Unmarshal(b, &cmd)
+				if r.Method != http.MethodPost || r.Header.Get("x-ctx") != "DEV" || cmd.ID != "id" {
+					t.Errorf("unexpected command request %s %q %+v", r.Method, r.Header.Get("x-ctx"), cmd)
+				}
+				w.WriteHeader(tt.status)
+			})
+
+			err := c.SendCommand(context.Background(), "DEV", CommandRequest{ID: "id", Command: "rit aws list bucket"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SendCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_GetExecution(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		wantReady bool
+		wantErr   bool
+	}{
+		{
+			name:      "ready",
+			status:    200,
+			body:      `{"status":"Ready","content":{"id":"test-07","startTime":1596808784,"endTime":1596808787}}`,
+			wantReady: true,
+		},
+		{name: "not found", status: 404, wantErr: true},
+		{name: "unauthorized", status: 401, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/executions/test-07" || r.Header.Get("x-ctx") != "DEV" {
+					t.Errorf("unexpected execution request %s %q", r.URL.Path, r.Header.Get("x-ctx"))
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			})
+
+			got, err := c.GetExecution("DEV", "test-07")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetExecution() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.Ready() != tt.wantReady {
+				t.Errorf("Ready() = %v, want %v", got.Ready(), tt.wantReady)
+			}
+			if tt.wantReady && got.Content.Duration() != 3*time.Second {
+				t.Errorf("Duration() = %v", got.Content.Duration())
+			}
+		})
+	}
+}
+
+func TestClient_CancelExecution(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		wantErr      bool
+		wantConflict bool
+	}{
+		{name: "accepted", status: 202},
+		{name: "no content", status: 204},
+		{name: "already finished", status: 409, wantErr: true, wantConflict: true},
+		{name: "not found", status: 404, wantErr: true},
+		{name: "not supported", status: 501, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/executions/test-07/cancel" || r.Header.Get("x-ctx") != "DEV" {
+					t.Errorf("unexpected cancel request %s %s %q", r.Method, r.URL.Path, r.Header.Get("x-ctx"))
+				}
+				w.WriteHeader(tt.status)
+			})
+
+			err := c.CancelExecution("DEV", "test-07")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CancelExecution() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if IsConflict(err) != tt.wantConflict {
+				t.Errorf("IsConflict(%v) = %v, want %v", err, IsConflict(err), tt.wantConflict)
+			}
+		})
+	}
+}
+
+func TestClient_ListExecutions(t *testing.T) {
+	c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/executions" || r.Header.Get("x-ctx") != "DEV" || q.Get("status") != "Ready" || q.Get("page") != "2" {
+			t.Errorf("unexpected executions request %s %q", r.URL, r.Header.Get("x-ctx"))
+		}
+		_, _ = w.Write([]byte(`{"total":3,"executions":[
+			{"status":"Ready","content":{"id":"old","startTime":1596808784}},
+			{"status":"Running","content":{"id":"running","startTime":1596808790}},
+			{"status":"Ready","content":{"id":"new","startTime":1596808800}}]}`))
+	})
+
+	got, err := c.ListExecutions("DEV", ExecutionFilter{Status: "Ready", Page: 2})
+	if err != nil {
+		t.Fatalf("ListExecutions() error = %v", err)
+	}
+	if len(got.Executions) != 2 || got.Executions[0].Content.ID != "new" || got.Executions[1].Content.ID != "old" {
+		t.Errorf("ListExecutions() = %+v, want new and old", got.Executions)
+	}
+}
+
+func TestClient_SetCredential(t *testing.T) {
+	c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		want := `{"service":"github","credential":{"token":"secret","username":"octocat"}}`
+		if r.URL.Path != "/credentials" || string(b) != want {
+			t.Errorf("unexpected credential request %s %s", r.URL.Path, b)
+		}
+		w.WriteHeader(201)
+	})
+
+	err := c.SetCredential("DEV", CredentialRequest{
+		Service:    "github",
+		Credential: map[string]string{"username": "octocat", "token": "secret"},
+	})
+	if err != nil {
+		t.Errorf("SetCredential() error = %v", err)
+	}
+}
+
+func TestClient_VerifyCredential(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          int
+		body            string
+		wantValid       bool
+		wantMessage     string
+		wantUnsupported bool
+	}{
+		{name: "valid", status: 200, body: `{"valid":true}`, wantValid: true},
+		{name: "invalid", status: 200, body: `{"valid":false,"message":"InvalidClientTokenId"}`, wantMessage: "InvalidClientTokenId"},
+		{name: "unprocessable", status: 422, wantMessage: "refused by the server with status 422"},
+		{name: "not supported", status: 404, wantUnsupported: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/credentials/verify" || r.Header.Get("x-ctx") != "DEV" {
+					t.Errorf("unexpected verify request %s %s %q", r.Method, r.URL.Path, r.Header.Get("x-ctx"))
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			})
+
+			got, err := c.VerifyCredential("DEV", CredentialRequest{Service: "aws", Credential: map[string]string{"accesskeyid": "AKIA"}})
+			if errors.Is(err, ErrVerifyUnsupported) != tt.wantUnsupported {
+				t.Fatalf("VerifyCredential() error = %v, wantUnsupported %v", err, tt.wantUnsupported)
+			}
+			if !tt.wantUnsupported && (err != nil || got.Valid != tt.wantValid || got.Message != tt.wantMessage) {
+				t.Errorf("VerifyCredential() = %+v, %v", got, err)
+			}
+		})
+	}
+}
+
+func TestClient_ListCredentials(t *testing.T) {
+	c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/credentials" || r.Header.Get("x-ctx") != "DEV" {
+			t.Errorf("unexpected credentials request %s %s %q", r.Method, r.URL.Path, r.Header.Get("x-ctx"))
+		}
+		_, _ = w.Write([]byte(`{"credentials":[{"service":"github","keys":["username","token"],"updatedAt":"2020-08-07T13:59:44Z"}]}`))
+	})
+
+	got, err := c.ListCredentials("DEV")
+	if err != nil || len(got.Credentials) != 1 || got.Credentials[0].Service != "github" || got.Credentials[0].UpdatedAt.IsZero() {
+		t.Errorf("ListCredentials() = %+v, error = %v", got, err)
+	}
+}
+
+func TestClient_DeleteCredential(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		wantErr      bool
+		wantNotFound bool
+	}{
+		{name: "deleted", status: 204},
+		{name: "not found", status: 404, wantErr: true, wantNotFound: true},
+		{name: "not supported", status: 405, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete || r.URL.Path != "/credentials/github" || r.Header.Get("x-ctx") != "DEV" {
+					t.Errorf("unexpected delete request %s %s %q", r.Method, r.URL.Path, r.Header.Get("x-ctx"))
+				}
+				w.WriteHeader(tt.status)
+			})
+
+			err := c.DeleteCredential("DEV", "github")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DeleteCredential() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if IsNotFound(err) != tt.wantNotFound {
+				t.Errorf("IsNotFound(%v) = %v, want %v", err, IsNotFound(err), tt.wantNotFound)
+			}
+		})
+	}
+}
+
+func TestClient_Authenticate(t *testing.T) {
+	logins := 0
+	c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		logins++
+		_, _ = w.Write([]byte(`{"token":"fresh","expires_in":3600}`))
+	})
+	c.Tokens = TokenStore{Path: filepath.Join(tempDir(t), "tokens.json")}
+
+	if err := c.Authenticate(); err != nil || c.Token() != "fresh" || logins != 1 {
+		t.Fatalf("Authenticate() error = %v, token %q, logins %d", err, c.Token(), logins)
+	}
+
+	other := NewClient(c.Host, c.Username, c.Password)
+	other.Tokens = c.Tokens
+	if err := other.Authenticate(); err != nil || other.Token() != "fresh" || logins != 1 {
+		t.Errorf("Authenticate() with cached token error = %v, token %q, logins %d", err, other.Token(), logins)
+	}
+}
+
+func TestClient_reloginOnUnauthorized(t *testing.T) {
+	c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/login":
+			_, _ = w.Write([]byte(`{"token":"fresh","expires_in":3600}`))
+		case r.Header.Get("x-authorization") != "fresh":
+			w.WriteHeader(401)
+		default:
+			_, _ = w.Write([]byte(`{"contexts":[{"name":"DEV"}]}`))
+		}
+	})
+	c.token = "revoked"
+
+	got, err := c.ListFormulas()
+	if err != nil || len(got.Contexts) != 1 {
+		t.Errorf("ListFormulas() = %+v, error = %v", got, err)
+	}
+	if c.Token() != "fresh" {
+		t.Errorf("Token() = %q, want fresh", c.Token())
+	}
+}
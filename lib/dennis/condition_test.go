@@ -0,0 +1,61 @@
+package dennis
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCondition_Met(t *testing.T) {
+	values := map[string]string{"size": "large"}
+	tests := []struct {
+		name string
+		cond *Condition
+		want bool
+	}{
+		{name: "no condition", want: true},
+		{name: "equal", cond: &Condition{Variable: "size", Operator: OperatorEqual, Value: "large"}, want: true},
+		{name: "not equal", cond: &Condition{Variable: "size", Operator: OperatorNotEqual, Value: "large"}},
+		{name: "in", cond: &Condition{Variable: "size", Operator: OperatorIn, Value: "medium, large"}, want: true},
+		{name: "not in", cond: &Condition{Variable: "size", Operator: OperatorIn, Value: "small,medium"}},
+		{name: "missing variable", cond: &Condition{Variable: "cups", Operator: OperatorNotEqual, Value: "1"}, want: true},
+		{name: "unknown operator", cond: &Condition{Variable: "size", Operator: ">", Value: "small"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cond.Met(values); got != tt.want {
+				t.Errorf("Met() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInputs_Resolve(t *testing.T) {
+	inputs := Inputs{
+		{Name: "delivery", Type: "bool", Default: "false"},
+		{Name: "address", Type: "text", Condition: &Condition{Variable: "delivery", Operator: OperatorEqual, Value: "true"}},
+		{Name: "floor", Type: "integer", Default: "0", Condition: &Condition{Variable: "address", Operator: OperatorNotEqual, Value: ""}},
+	}
+	tests := []struct {
+		name  string
+		given map[string]string
+		want  map[string]string
+	}{
+		{
+			name:  "defaults",
+			given: map[string]string{"address": "Rua 1"},
+			want:  map[string]string{"delivery": "false"},
+		},
+		{
+			name:  "chained conditions",
+			given: map[string]string{"delivery": "true", "address": "Rua 1"},
+			want:  map[string]string{"delivery": "true", "address": "Rua 1", "floor": "0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := inputs.Resolve(tt.given); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resolve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package dennis
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteCredentials(t *testing.T) {
+	info := CredentialInfo{Service: "github", Keys: []string{"username", "token"}, UpdatedAt: time.Unix(1596808784, 0)}
+	out := NewCredentialOutput("DEV", info)
+	if out.Keys[0] != "token" || out.UpdatedAt != "2020-08-07T13:59:44Z" {
+		t.Fatalf("NewCredentialOutput() = %+v", out)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := WriteCredentials(buf, OutputText, []CredentialOutput{out}); err != nil {
+		t.Fatalf("WriteCredentials() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "DEV      github    token, username  2020-08-07T13:59:44Z\n") {
+		t.Errorf("WriteCredentials() = \n%s", buf)
+	}
+
+	buf.Reset()
+	if err := WriteCredentials(buf, OutputJSON, []CredentialOutput{out}); err != nil {
+		t.Fatalf("WriteCredentials() error = %v", err)
+	}
+	var got []CredentialOutput
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil || len(got) != 1 || got[0].Provider != "github" {
+		t.Errorf("WriteCredentials() = %s, decoding error = %v", buf, err)
+	}
+}
@@ -0,0 +1,42 @@
+package dennis
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestEnv(t *testing.T) {
+	tests := []struct {
+		name         string
+		value        string
+		wantString   string
+		wantInput    string
+		wantInt      int
+		wantDuration time.Duration
+	}{
+		{name: "unset", wantString: "text"},
+		{name: "placeholder", value: "none", wantString: "none"},
+		{name: "integer", value: "5", wantString: "5", wantInput: "5", wantInt: 5},
+		{name: "duration", value: "5m", wantString: "5m", wantInput: "5m", wantDuration: 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("DENNIS_TEST", tt.value)
+			defer os.Unsetenv("DENNIS_TEST")
+
+			if got := EnvString("DENNIS_TEST", "text"); got != tt.wantString {
+				t.Errorf("EnvString() = %s, want %s", got, tt.wantString)
+			}
+			if got := EnvInput("DENNIS_TEST", "none"); got != tt.wantInput {
+				t.Errorf("EnvInput() = %s, want %s", got, tt.wantInput)
+			}
+			if got := EnvInt("DENNIS_TEST"); got != tt.wantInt {
+				t.Errorf("EnvInt() = %d, want %d", got, tt.wantInt)
+			}
+			if got := EnvDuration("DENNIS_TEST"); got != tt.wantDuration {
+				t.Errorf("EnvDuration() = %s, want %s", got, tt.wantDuration)
+			}
+		})
+	}
+}
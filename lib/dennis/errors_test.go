@@ -0,0 +1,56 @@
+package dennis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "success", err: nil, want: ExitOK},
+		{name: "timeout", err: fmt.Errorf("%w: error getting execution", ErrTimeout), want: ExitTimeout},
+		{name: "interrupted", err: &url.Error{Op: "Get", URL: "/executions/id", Err: context.Canceled}, want: ExitInterrupted},
+		{name: "unauthorized", err: statusError(401, "login failed! Verify your credentials"), want: ExitAuth},
+		{name: "forbidden", err: fmt.Errorf("wrapped: %w", statusError(403, "authorization failed")), want: ExitAuth},
+		{name: "not found", err: statusError(404, "execution not found"), want: ExitNotFound},
+		{name: "transport", err: fmt.Errorf("error getting execution: %w", &url.Error{Op: "Get", URL: "/", Err: errors.New("connection refused")}), want: ExitTransport},
+		{name: "server error", err: statusError(500, "command failed"), want: ExitFailure},
+		{name: "other", err: errors.New("invalid invocation"), want: ExitFailure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusExitCode(t *testing.T) {
+	for code, want := range map[int]int{
+		0:   0,
+		2:   2,
+		63:  63,
+		64:  ExitRemote,
+		77:  ExitRemote,
+		79:  ExitRemote,
+		80:  80,
+		124: ExitRemote,
+		130: ExitRemote,
+		131: 131,
+		255: 255,
+		256: ExitFailure,
+		-1:  ExitFailure,
+	} {
+		if got := StatusExitCode(code); got != want {
+			t.Errorf("StatusExitCode(%d) = %d, want %d", code, got, want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package dennis
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExecutionFilter_Query(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter ExecutionFilter
+		want   string
+	}{
+		{
+			name: "defaults",
+			want: "order=desc&page=1&size=20&sort=startTime",
+		},
+		{
+			name: "every field",
+			filter: ExecutionFilter{
+				Command:   "rit aws list bucket",
+				Status:    "Ready",
+				User:      "user",
+				Since:     time.Unix(1596808784, 0),
+				Until:     time.Unix(1596808787, 0),
+				Page:      3,
+				Size:      5,
+				Ascending: true,
+			},
+			want: "command=rit+aws+list+bucket&order=asc&page=3&since=1596808784&size=5&sort=startTime&status=Ready&until=1596808787&user=user",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Query().Encode(); got != tt.want {
+				t.Errorf("Query() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecutionFilter_Match(t *testing.T) {
+	exec := ExecutionResponse{
+		Status: StatusReady,
+		Content: Content{
+			Command:   "rit aws list bucket",
+			User:      "user",
+			StartTime: ExecTime(time.Unix(1596808784, 0)),
+		},
+	}
+	tests := []struct {
+		name   string
+		filter ExecutionFilter
+		want   bool
+	}{
+		{name: "empty", want: true},
+		{name: "every field", filter: ExecutionFilter{Command: "rit aws list bucket", Status: "ready", User: "user"}, want: true},
+		{name: "other command", filter: ExecutionFilter{Command: "rit coffee"}},
+		{name: "other status", filter: ExecutionFilter{Status: "Running"}},
+		{name: "other user", filter: ExecutionFilter{User: "other"}},
+		{name: "inside range", filter: ExecutionFilter{Since: time.Unix(1596808000, 0), Until: time.Unix(1596809000, 0)}, want: true},
+		{name: "started before since", filter: ExecutionFilter{Since: time.Unix(1596808785, 0)}},
+		{name: "started after until", filter: ExecutionFilter{Until: time.Unix(1596808783, 0)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(exec); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
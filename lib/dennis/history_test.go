@@ -0,0 +1,90 @@
+package dennis
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestNewHistoryEntry(t *testing.T) {
+	form := Formula{
+		Command: "rit aws list bucket",
+		Inputs: Inputs{
+			{Name: "access_key", Type: "CREDENTIAL_AWS_ACCESSKEYID"},
+			{Name: "token", Type: "password"},
+			{Name: "region", Type: "text"},
+		},
+	}
+	inv := Invocation{
+		Context: "DEV",
+		Command: form.Command,
+		Inputs:  map[string]string{"access_key": "AKIA", "token": "secret", "region": "sa-east-1"},
+	}
+
+	e := NewHistoryEntry("https://dennis", "test-07", inv, form)
+	want := map[string]string{"access_key": redacted, "token": redacted, "region": "sa-east-1"}
+	for name, value := range want {
+		if e.Inputs[name] != value {
+			t.Errorf("Inputs[%s] = %q, want %q", name, e.Inputs[name], value)
+		}
+	}
+	if inv.Inputs["token"] != "secret" {
+		t.Error("NewHistoryEntry() changed the invocation inputs")
+	}
+}
+
+func TestHistory(t *testing.T) {
+	h := History{Path: filepath.Join(tempDir(t), "rocket", "history.jsonl")}
+	if got, err := h.Recent("", 0); err != nil || len(got) != 0 {
+		t.Fatalf("Recent() on a missing history = %v, %v", got, err)
+	}
+
+	for _, e := range []HistoryEntry{
+		{ID: "1", Context: "DEV", Endpoint: "https://dev"},
+		{ID: "2", Context: "QA", Endpoint: "https://stg"},
+		{ID: "3", Context: "DEV", Endpoint: "https://dev"},
+	} {
+		if err := h.Append(e); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	// a line cut by a crash must not hide the others
+	f, _ := os.OpenFile(h.Path, os.O_APPEND|os.O_WRONLY, 0600)
+	_, _ = f.WriteString("{\"id\":\"4\",\"cont\n")
+	f.Close()
+
+	tests := []struct {
+		name string
+		host string
+		n    int
+		want []string
+	}{
+		{name: "all", want: []string{"3", "2", "1"}},
+		{name: "newest", n: 2, want: []string{"3", "2"}},
+		{name: "by endpoint", host: "https://dev", want: []string{"3", "1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := h.Recent(tt.host, tt.n)
+			if err != nil {
+				t.Fatalf("Recent() error = %v", err)
+			}
+			ids := make([]string, len(got))
+			for i, e := range got {
+				ids[i] = e.ID
+			}
+			if !reflect.DeepEqual(ids, tt.want) {
+				t.Errorf("Recent() = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+
+	if e, ok, _ := h.Find("2"); !ok || e.Context != "QA" {
+		t.Errorf("Find() = %+v, %v", e, ok)
+	}
+	if info, _ := os.Stat(h.Path); info.Mode().Perm() != 0600 {
+		t.Errorf("history mode = %v, want 0600", info.Mode().Perm())
+	}
+}
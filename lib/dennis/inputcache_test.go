@@ -0,0 +1,26 @@
+package dennis
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestInputCache(t *testing.T) {
+	c := InputCache{Path: filepath.Join(tempDir(t), "inputs.json")}
+	for _, v := range []string{"us-east-1", "sa-east-1", "eu-west-1", "us-east-1"} {
+		if err := c.Put("rit aws list bucket", "region", v, 2); err != nil {
+			t.Fatalf("Put() error = %v", err)
+		}
+	}
+
+	if got, want := c.Get("rit aws list bucket", "region"), []string{"us-east-1", "eu-west-1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Get() = %v, want %v", got, want)
+	}
+	if got := c.Get("rit coffee", "region"); got != nil {
+		t.Errorf("Get() of another command = %v, want nil", got)
+	}
+	if got := (InputCache{}).Get("rit aws list bucket", "region"); got != nil {
+		t.Errorf("Get() without a path = %v, want nil", got)
+	}
+}
@@ -0,0 +1,209 @@
+package dennis
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var coffeeFormulas = FormulasResponse{
+	Contexts: Contexts{{Name: "DEV"}},
+	Formulas: Formulas{
+		{
+			Command: "rit scaffold generate coffee-go",
+			Inputs: Inputs{
+				{Name: "name", Type: "text"},
+				{Name: "coffee_type", Type: "text", Default: "espresso", Items: Items{"espresso", "latte"}},
+				{Name: "delivery", Type: "bool", Default: "false", Items: Items{"false", "true"}},
+				{Name: "address", Type: "text", Condition: &Condition{Variable: "delivery", Operator: OperatorEqual, Value: "true"}},
+			},
+		},
+		{
+			Command: "rit coffee order",
+			Inputs: Inputs{
+				{Name: "cups", Type: "integer", Default: "1", Min: bound(1), Max: bound(4)},
+				{Name: "phone", Type: "text", Required: true, Pattern: &Pattern{Regex: "^[0-9]{8,}$", MismatchText: "type at least 8 digits"}},
+			},
+		},
+	},
+}
+
+func TestInvocation_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		inv  Invocation
+		want ValidationError
+	}{
+		{
+			name: "valid",
+			inv: Invocation{
+				Context: "DEV",
+				Command: "rit scaffold generate coffee-go",
+				Inputs:  map[string]string{"name": "Dennis", "delivery": "true", "address": "Rua 1"},
+			},
+		},
+		{
+			name: "missing input of a condition that holds",
+			inv: Invocation{
+				Context: "DEV",
+				Command: "rit scaffold generate coffee-go",
+				Inputs:  map[string]string{"name": "Dennis", "delivery": "true"},
+			},
+			want: ValidationError{`missing input "address"`},
+		},
+		{
+			name: "missing everything",
+			inv:  Invocation{},
+			want: ValidationError{"missing context", "missing command"},
+		},
+		{
+			name: "unknown formula",
+			inv:  Invocation{Context: "QA", Command: "rit coffee"},
+			want: ValidationError{`context "QA" not found`, `formula "rit coffee" not found`},
+		},
+		{
+			name: "invalid inputs",
+			inv: Invocation{
+				Context: "DEV",
+				Command: "rit scaffold generate coffee-go",
+				Inputs:  map[string]string{"size": "large", "coffee_type": "mocha", "delivery": "yes"},
+			},
+			want: ValidationError{
+				`unknown input "size"`,
+				`missing input "name"`,
+				`input "coffee_type" must be one of espresso, latte`,
+				`input "delivery" must be true or false`,
+			},
+		},
+		{
+			name: "rules",
+			inv: Invocation{
+				Context: "DEV",
+				Command: "rit coffee order",
+				Inputs:  map[string]string{"cups": "5", "phone": "1234"},
+			},
+			want: ValidationError{
+				`input "cups" must be at most 4`,
+				`input "phone": type at least 8 digits`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.inv.Validate(coffeeFormulas)
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if got, ok := err.(ValidationError); !ok || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() error = %#v, want %#v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvocation_CommandRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		inputs map[string]string
+		want   Inputs
+	}{
+		{
+			name:   "delivery",
+			inputs: map[string]string{"delivery": "true", "name": "Dennis", "address": "Rua 1"},
+			want: Inputs{
+				{Name: "name", Type: "text", Value: "Dennis"},
+				{Name: "coffee_type", Type: "text", Value: "espresso"},
+				{Name: "delivery", Type: "bool", Value: "true"},
+				{Name: "address", Type: "text", Value: "Rua 1"},
+			},
+		},
+		{
+			name:   "no delivery",
+			inputs: map[string]string{"name": "Dennis", "address": "Rua 1"},
+			want: Inputs{
+				{Name: "name", Type: "text", Value: "Dennis"},
+				{Name: "coffee_type", Type: "text", Value: "espresso"},
+				{Name: "delivery", Type: "bool", Value: "false"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv := Invocation{Context: "DEV", Command: "rit scaffold generate coffee-go", Inputs: tt.inputs}
+			got := inv.CommandRequest("id", coffeeFormulas.Formulas[0])
+			want := CommandRequest{ID: "id", Command: "rit scaffold generate coffee-go", Inputs: tt.want}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("CommandRequest() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestLoadInvocation(t *testing.T) {
+	want := Invocation{
+		Context: "DEV",
+		Command: "rit aws list bucket",
+		Inputs:  map[string]string{"region": "sa-east-1"},
+	}
+	files := map[string]string{
+		"invocation.yml":  "context: DEV\ncommand: rit aws list bucket\ninputs:\n  region: sa-east-1\n",
+		"invocation.json": `{"context": "DEV", "command": "rit aws list bucket", "inputs": {"region": "sa-east-1"}}`,
+	}
+	dir := tempDir(t)
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := LoadInvocation(path)
+			if err != nil || !reflect.DeepEqual(got, want) {
+				t.Errorf("LoadInvocation() = %+v, %v, want %+v", got, err, want)
+			}
+		})
+	}
+}
+
+func TestInput_check(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   Input
+		value   string
+		wantErr bool
+	}{
+		{name: "item", input: Input{Type: "text", Items: Items{"espresso"}}, value: "espresso"},
+		{name: "not an item", input: Input{Type: "text", Items: Items{"espresso"}}, value: "mocha", wantErr: true},
+		{name: "new value of a cached input", input: Input{Type: "text", Items: Items{"espresso"}, Cache: &Cache{Active: true}}, value: "mocha"},
+		{name: "inactive cache", input: Input{Type: "text", Items: Items{"espresso"}, Cache: &Cache{}}, value: "mocha", wantErr: true},
+		{name: "bool", input: Input{Type: "bool"}, value: "yes", wantErr: true},
+		{name: "required", input: Input{Type: "text", Required: true}, value: "", wantErr: true},
+		{name: "optional", input: Input{Type: "integer", Min: bound(1)}, value: ""},
+		{name: "number", input: Input{Type: "number", Min: bound(0.5), Max: bound(2)}, value: "1.5"},
+		{name: "not a number", input: Input{Type: "number"}, value: "one", wantErr: true},
+		{name: "integer out of range", input: Input{Type: "integer", Max: bound(10)}, value: "11", wantErr: true},
+		{name: "not an integer", input: Input{Type: "integer"}, value: "1.5", wantErr: true},
+		{name: "date", input: Input{Type: "date"}, value: "2020-08-07"},
+		{name: "not a date", input: Input{Type: "date"}, value: "08/07/2020", wantErr: true},
+		{name: "path", input: Input{Type: "path"}, value: "/tmp/coffee"},
+		{name: "too short", input: Input{Type: "text", Min: bound(3)}, value: "ab", wantErr: true},
+		{name: "pattern", input: Input{Type: "text", Pattern: &Pattern{Regex: "^[a-z]+-[0-9]$"}}, value: "us-1"},
+		{name: "pattern mismatch", input: Input{Type: "text", Pattern: &Pattern{Regex: "^[a-z]+-[0-9]$"}}, value: "US1", wantErr: true},
+		{name: "invalid pattern", input: Input{Type: "text", Pattern: &Pattern{Regex: "("}}, value: "x", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.input.Check(tt.value); (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func bound(f float64) *float64 {
+	return &f
+}
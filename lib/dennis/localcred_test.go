@@ -0,0 +1,62 @@
+package dennis
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseCredentialType(t *testing.T) {
+	tests := []struct {
+		typ          string
+		wantProvider string
+		wantField    string
+		wantOk       bool
+	}{
+		{typ: "CREDENTIAL_AWS_ACCESSKEYID", wantProvider: "aws", wantField: "accesskeyid", wantOk: true},
+		{typ: "CREDENTIAL_GITHUB_TOKEN", wantProvider: "github", wantField: "token", wantOk: true},
+		{typ: "CREDENTIAL_AWS"},
+		{typ: "text"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			provider, field, ok := ParseCredentialType(tt.typ)
+			if provider != tt.wantProvider || field != tt.wantField || ok != tt.wantOk {
+				t.Errorf("ParseCredentialType() = %q, %q, %v", provider, field, ok)
+			}
+		})
+	}
+}
+
+func TestLocalCredentials_Resolve(t *testing.T) {
+	home := tempDir(t)
+	write := func(path, content string) {
+		t.Helper()
+		path = filepath.Join(home, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("credentials/default/aws", `{"service":"aws","credential":{"accesskeyid":"default-key"}}`)
+	write("credentials/prod/aws", `{"service":"aws","credential":{"accesskeyid":"prod-key"}}`)
+
+	l := LocalCredentials{Home: home}
+	if got, ok := l.Resolve("CREDENTIAL_AWS_ACCESSKEYID"); !ok || got != "default-key" {
+		t.Errorf("Resolve() = %q, %v, want default-key", got, ok)
+	}
+	if _, ok := l.Resolve("CREDENTIAL_AWS_SECRETACCESSKEY"); ok {
+		t.Error("Resolve() of a missing field should fail")
+	}
+	if _, ok := l.Resolve("CREDENTIAL_GITHUB_TOKEN"); ok {
+		t.Error("Resolve() of a missing provider should fail")
+	}
+
+	write("contexts", `{"current_context":"prod","contexts":["prod"]}`)
+	if got, ok := l.Resolve("CREDENTIAL_AWS_ACCESSKEYID"); !ok || got != "prod-key" {
+		t.Errorf("Resolve() in the prod context = %q, %v, want prod-key", got, ok)
+	}
+}
@@ -0,0 +1,106 @@
+package dennis
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestLogTail_Next(t *testing.T) {
+	c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/executions/test-07/logs" || r.Header.Get("x-ctx") != "DEV" {
+			t.Errorf("unexpected logs request %s %q", r.URL, r.Header.Get("x-ctx"))
+		}
+		switch r.URL.Query().Get("offset") {
+		case "0":
+			_, _ = w.Write([]byte(`{"offset":6,"chunks":[{"stream":"stdout","data":"first\n"}]}`))
+		case "6":
+			_, _ = w.Write([]byte(`{"offset":13,"chunks":[{"stream":"stderr","data":"second\n"}],"done":true}`))
+		default:
+			t.Errorf("unexpected offset %s", r.URL.Query().Get("offset"))
+		}
+	})
+
+	tail := &LogTail{Client: c, Context: "DEV", ID: "test-07"}
+	var got []string
+	for i := 0; i < 2; i++ {
+		chunks, err := tail.Next(context.Background())
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		for _, chunk := range chunks {
+			got = append(got, chunk.Stream+":"+chunk.Data)
+		}
+	}
+	if want := "stdout:first\n,stderr:second\n"; strings.Join(got, ",") != want || tail.Offset != 13 {
+		t.Errorf("Next() = %q at %d, want %q at 13", got, tail.Offset, want)
+	}
+}
+
+func TestClient_GetLogs(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          int
+		body            string
+		wantUnsupported bool
+		wantNotFound    bool
+	}{
+		{name: "not implemented", status: 501, wantUnsupported: true},
+		{name: "method not allowed", status: 405, wantUnsupported: true},
+		{name: "not a logs response", status: 200, body: `<html>`, wantUnsupported: true},
+		{name: "not found", status: 404, wantNotFound: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			})
+
+			_, err := c.GetLogs(context.Background(), "DEV", "test-07", 0)
+			if got := errors.Is(err, ErrLogsUnsupported); got != tt.wantUnsupported {
+				t.Errorf("GetLogs() error = %v, want unsupported %v", err, tt.wantUnsupported)
+			}
+			if got := IsNotFound(err); got != tt.wantNotFound {
+				t.Errorf("GetLogs() error = %v, want not found %v", err, tt.wantNotFound)
+			}
+		})
+	}
+}
+
+func TestLogTail_Poll(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		execErr error
+		want    string
+		wantOff bool
+	}{
+		{name: "output", status: 200, want: "out:first\n,err:second\n"},
+		{name: "unsupported", status: 501, wantOff: true},
+		{name: "queued", status: 404, execErr: statusError(404, "execution not found")},
+		{name: "no logs of a found execution", status: 404, wantOff: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{"offset":13,"chunks":[{"stream":"stdout","data":"first\n"},{"stream":"stderr","data":"second\n"}]}`))
+			})
+
+			var stdout, stderr strings.Builder
+			tail := &LogTail{Client: c, Context: "DEV", ID: "test-07", Stdout: &stdout, Stderr: &stderr}
+			tail.Print(tail.Poll(context.Background(), tt.execErr))
+
+			got := ""
+			if stdout.Len() > 0 || stderr.Len() > 0 {
+				got = "out:" + stdout.String() + ",err:" + stderr.String()
+			}
+			if got != tt.want || tail.Streamed() != (tt.want != "") || tail.off != tt.wantOff {
+				t.Errorf("Poll() printed %q, off %v, want %q, off %v", got, tail.off, tt.want, tt.wantOff)
+			}
+		})
+	}
+}
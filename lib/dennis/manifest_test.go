@@ -0,0 +1,60 @@
+package dennis
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLoadManifest(t *testing.T) {
+	path := filepath.Join(tempDir(t), "manifest.yml")
+	content := `
+parallel: 2
+timeout: 5m
+invocations:
+  - context: DEV
+    command: rit scaffold generate coffee-go
+    inputs:
+      name: Dennis
+  - context: DEV
+    command: rit scaffold generate coffee-go
+    inputs:
+      name: Ritchie
+`
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := LoadManifest(path)
+	if err != nil {
+		t.Fatalf("LoadManifest() error = %v", err)
+	}
+	if m.Parallel != 2 || len(m.Invocations) != 2 || m.Invocations[1].Inputs["name"] != "Ritchie" {
+		t.Errorf("LoadManifest() = %+v", m)
+	}
+	if d, err := m.TimeoutDuration(time.Minute); err != nil || d != 5*time.Minute {
+		t.Errorf("TimeoutDuration() = %v, %v", d, err)
+	}
+}
+
+func TestManifest_Validate(t *testing.T) {
+	m := Manifest{
+		Invocations: []Invocation{
+			{Context: "DEV", Command: "rit scaffold generate coffee-go", Inputs: map[string]string{"name": "Dennis"}},
+			{Context: "QA", Command: "rit scaffold generate coffee-go"},
+		},
+	}
+
+	want := ValidationError{
+		`invocation 2: context "QA" not found`,
+		`invocation 2: missing input "name"`,
+	}
+	if err := m.Validate(coffeeFormulas); !reflect.DeepEqual(err, want) {
+		t.Errorf("Validate() error = %#v, want %#v", err, want)
+	}
+	if err := (Manifest{}).Validate(coffeeFormulas); err == nil {
+		t.Error("Validate() of an empty manifest should fail")
+	}
+}
@@ -0,0 +1,37 @@
+package dennis
+
+import (
+	"encoding/json"
+	"os"
+	"runtime"
+	"testing"
+)
+
+func TestLocalMetadata(t *testing.T) {
+	os.Setenv("RIT_VERSION", "2.0.0")
+	defer os.Unsetenv("RIT_VERSION")
+
+	got := LocalMetadata()
+	if got.OS != runtime.GOOS+"/"+runtime.GOARCH || got.ClientVersion != Version || got.RitVersion != "2.0.0" {
+		t.Errorf("LocalMetadata() = %+v", got)
+	}
+}
+
+func TestCommandRequest_metadata(t *testing.T) {
+	tests := []struct {
+		name string
+		meta *Metadata
+		want string
+	}{
+		{name: "without metadata", want: `{"id":"id"}`},
+		{name: "unknown address", meta: &Metadata{OS: "linux/amd64"}, want: `{"id":"id","metadata":{"os":"linux/amd64"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(CommandRequest{ID: "id", Metadata: tt.meta})
+			if err != nil || string(b) != tt.want {
+				t.Errorf("Marshal() = %s, %v, want %s", b, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package dennis
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+var readyExecution = ExecutionResponse{
+	Status: StatusReady,
+	Content: Content{
+		ID:            "test-07",
+		StatusCode:    2,
+		User:          "user",
+		StartTime:     ExecTime(time.Unix(1596808784, 0)),
+		EndTime:       ExecTime(time.Unix(1596808787, 0)),
+		FormulaOut:    "out\n",
+		FormulaErr:    "first\nsecond\n",
+		FormulaInputs: Inputs{{Name: "region", Value: "sa-east-1"}},
+	},
+}
+
+func TestWriteExecution(t *testing.T) {
+	want := NewExecutionOutput("DEV", readyExecution)
+	if want.Duration != "3s" || want.StartTime != "2020-08-07T13:59:44Z" || want.Inputs["region"] != "sa-east-1" {
+		t.Fatalf("NewExecutionOutput() = %+v", want)
+	}
+
+	tests := []struct {
+		format string
+		decode func([]byte, interface{}) error
+	}{
+		{format: OutputJSON, decode: json.Unmarshal},
+		{format: OutputYAML, decode: yaml.Unmarshal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			if err := WriteExecution(buf, tt.format, want); err != nil {
+				t.Fatalf("WriteExecution() error = %v", err)
+			}
+
+			got := ExecutionOutput{}
+			if err := tt.decode(buf.Bytes(), &got); err != nil {
+				t.Fatalf("decoding %s: %v", buf, err)
+			}
+			if got.ID != want.ID || got.StatusCode != 2 || got.Stderr != want.Stderr || got.Inputs["region"] != "sa-east-1" {
+				t.Errorf("WriteExecution() = %+v, want %+v", got, want)
+			}
+		})
+	}
+
+	t.Run(OutputTable, func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		if err := WriteExecution(buf, OutputTable, want); err != nil {
+			t.Fatalf("WriteExecution() error = %v", err)
+		}
+		for _, line := range []string{
+			"ID           test-07",
+			"STATUS CODE  2",
+			"INPUTS       region=sa-east-1",
+			"STDERR       first",
+			"             second",
+		} {
+			if !strings.Contains(buf.String(), line+"\n") {
+				t.Errorf("WriteExecution() = \n%s\nwant line %q", buf, line)
+			}
+		}
+	})
+
+	if err := WriteExecution(&bytes.Buffer{}, "xml", want); err == nil {
+		t.Error("WriteExecution() with an invalid format should fail")
+	}
+}
@@ -0,0 +1,38 @@
+package dennis
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestHistory_SelectExecution(t *testing.T) {
+	if IsTerminal() {
+		t.Skip("the missing values would be prompted")
+	}
+	h := History{Path: filepath.Join(tempDir(t), "history.jsonl")}
+	if err := h.Append(HistoryEntry{ID: "test-07", Context: "QA", Endpoint: "https://dev"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		id      string
+		ctx     string
+		wantCtx string
+		wantErr bool
+	}{
+		{name: "given", id: "test-06", ctx: "DEV", wantCtx: "DEV"},
+		{name: "context of the history", id: "test-07", wantCtx: "QA"},
+		{name: "given context wins", id: "test-07", ctx: "DEV", wantCtx: "DEV"},
+		{name: "unknown context", id: "test-06", wantErr: true},
+		{name: "missing ID", ctx: "DEV", wantCtx: "DEV", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, ctx, err := h.SelectExecution("https://dev", tt.id, tt.ctx)
+			if (err != nil) != tt.wantErr || id != tt.id || ctx != tt.wantCtx {
+				t.Errorf("SelectExecution() = %q, %q, %v, want %q, %q", id, ctx, err, tt.id, tt.wantCtx)
+			}
+		})
+	}
+}
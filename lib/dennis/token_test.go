@@ -0,0 +1,67 @@
+package dennis
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func tempDir(t *testing.T) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "dennis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func TestTokenStore(t *testing.T) {
+	now := time.Now().Unix()
+	tests := []struct {
+		name   string
+		resp   LoginResponse
+		wantOk bool
+	}{
+		{name: "ttl in the future", resp: LoginResponse{TTL: now + 3600}, wantOk: true},
+		{name: "ttl in the past", resp: LoginResponse{TTL: now - 3600}},
+		{name: "small ttl", resp: LoginResponse{TTL: 3600}},
+		{name: "ttl about to expire", resp: LoginResponse{TTL: now + 10}},
+		{name: "expires_at in the future", resp: LoginResponse{ExpiresAt: now + 3600, TTL: now - 3600}, wantOk: true},
+		{name: "expires_at in the past", resp: LoginResponse{ExpiresAt: now - 3600}},
+		{name: "expires_in", resp: LoginResponse{ExpiresIn: 3600}, wantOk: true},
+		{name: "long expires_in", resp: LoginResponse{ExpiresIn: 30 * 365 * 24 * 3600}, wantOk: true},
+		{name: "expires_in about to expire", resp: LoginResponse{ExpiresIn: 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := TokenStore{Path: filepath.Join(tempDir(t), "tokens.json")}
+			resp := tt.resp
+			resp.Token = "token"
+			if err := s.Put("https://dennis", "user", resp); err != nil {
+				t.Fatalf("Put() error = %v", err)
+			}
+
+			if _, ok := s.Get("https://dennis", "user"); ok != tt.wantOk {
+				t.Errorf("Get() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if _, ok := s.Get("https://other", "user"); ok {
+				t.Error("Get() returned a token of another endpoint")
+			}
+		})
+	}
+}
+
+func TestTokenStore_Delete(t *testing.T) {
+	s := TokenStore{Path: filepath.Join(tempDir(t), "tokens.json")}
+	_ = s.Put("https://dennis", "user", LoginResponse{Token: "token", ExpiresIn: 3600})
+
+	if err := s.Delete("https://dennis", "user"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, ok := s.Get("https://dennis", "user"); ok {
+		t.Error("Get() returned a deleted token")
+	}
+}
@@ -0,0 +1,138 @@
+package dennis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ErrTimeout is returned by WaitExecution when the execution is not ready
+// before the timeout.
+var ErrTimeout = errors.New("timeout awaiting execution")
+
+// ErrExecutionCanceled is returned by WaitExecution when the execution was
+// canceled before finishing.
+var ErrExecutionCanceled = errors.New("execution canceled")
+
+// WaitOptions configures how WaitExecution polls an execution. The delay
+// between polls starts at Interval and grows by Multiplier up to MaxInterval,
+// randomized by ±Jitter (a fraction of the delay).
+type WaitOptions struct {
+	Timeout     time.Duration
+	Interval    time.Duration
+	MaxInterval time.Duration
+	Multiplier  float64
+	Jitter      float64
+
+	// OnPoll, when set, is called after every poll.
+	OnPoll func(resp ExecutionResponse, err error)
+}
+
+var (
+	jitterMu   sync.Mutex
+	jitterRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+func DefaultWaitOptions() WaitOptions {
+	return WaitOptions{
+		Timeout:     60 * time.Second,
+		Interval:    time.Second,
+		MaxInterval: 10 * time.Second,
+		Multiplier:  1.5,
+		Jitter:      0.2,
+	}
+}
+
+// WaitExecution polls the execution until it is ready or canceled, the timeout
+// expires or goCtx is canceled, with a single request in flight at a time.
+// Failed polls are retried and the last failure is reported on timeout,
+// except for authorization failures, which are returned right away.
+func (c *Client) WaitExecution(goCtx context.Context, ctx, id string, opts WaitOptions) (ExecutionResponse, error) {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		goCtx, cancel = context.WithTimeout(goCtx, opts.Timeout)
+		defer cancel()
+	}
+
+	delay := opts.Interval
+	for {
+		execResp, err := c.getExecution(goCtx, ctx, id)
+		if goCtx.Err() == nil && opts.OnPoll != nil {
+			opts.OnPoll(execResp, err)
+		}
+		if err == nil && execResp.Ready() {
+			return execResp, nil
+		}
+		if err == nil && execResp.Canceled() {
+			return execResp, ErrExecutionCanceled
+		}
+		if IsUnauthorized(err) {
+			return execResp, err
+		}
+
+		timer := time.NewTimer(opts.jitter(delay))
+		select {
+		case <-goCtx.Done():
+			timer.Stop()
+			return execResp, waitError(goCtx.Err(), err)
+		case <-timer.C:
+		}
+		delay = opts.next(delay)
+	}
+}
+
+// InterruptContext returns a context canceled on Ctrl-C, so waiting stops
+// without leaving requests behind. The returned func stops listening.
+func InterruptContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case <-sig:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(sig)
+		cancel()
+	}
+}
+
+func (opts WaitOptions) next(delay time.Duration) time.Duration {
+	if opts.Multiplier > 1 {
+		delay = time.Duration(float64(delay) * opts.Multiplier)
+	}
+	if opts.MaxInterval > 0 && delay > opts.MaxInterval {
+		delay = opts.MaxInterval
+	}
+	return delay
+}
+
+func (opts WaitOptions) jitter(delay time.Duration) time.Duration {
+	if opts.Jitter <= 0 {
+		return delay
+	}
+	jitterMu.Lock()
+	f := 1 + opts.Jitter*(2*jitterRand.Float64()-1)
+	jitterMu.Unlock()
+	return time.Duration(float64(delay) * f)
+}
+
+func waitError(ctxErr, lastErr error) error {
+	if ctxErr == context.DeadlineExceeded {
+		if lastErr != nil {
+			return fmt.Errorf("%w: %s", ErrTimeout, lastErr)
+		}
+		return ErrTimeout
+	}
+	return ctxErr
+}
@@ -0,0 +1,118 @@
+package dennis
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func fastWait(timeout time.Duration) WaitOptions {
+	return WaitOptions{
+		Timeout:     timeout,
+		Interval:    time.Millisecond,
+		MaxInterval: 5 * time.Millisecond,
+		Multiplier:  2,
+	}
+}
+
+func TestClient_WaitExecution(t *testing.T) {
+	var polls, inFlight, maxInFlight int32
+	c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		if n > atomic.LoadInt32(&maxInFlight) {
+			atomic.StoreInt32(&maxInFlight, n)
+		}
+
+		switch atomic.AddInt32(&polls, 1) {
+		case 1:
+			w.WriteHeader(404)
+		case 2:
+			w.WriteHeader(500)
+		case 3:
+			_, _ = w.Write([]byte(`{"status":"Running"}`))
+		default:
+			_, _ = w.Write([]byte(`{"status":"Ready","content":{"id":"id","statusCode":2}}`))
+		}
+	})
+
+	var seen int
+	opts := fastWait(time.Second)
+	opts.OnPoll = func(ExecutionResponse, error) { seen++ }
+
+	got, err := c.WaitExecution(context.Background(), "DEV", "id", opts)
+	if err != nil || !got.Ready() || got.Content.StatusCode != 2 {
+		t.Fatalf("WaitExecution() = %+v, %v", got, err)
+	}
+	if polls != 4 || seen != 4 {
+		t.Errorf("polls = %d, OnPoll calls = %d, want 4", polls, seen)
+	}
+	if maxInFlight != 1 {
+		t.Errorf("max requests in flight = %d, want 1", maxInFlight)
+	}
+}
+
+func TestClient_WaitExecution_timeout(t *testing.T) {
+	c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(500)
+	})
+
+	_, err := c.WaitExecution(context.Background(), "DEV", "id", fastWait(20*time.Millisecond))
+	if !errors.Is(err, ErrTimeout) {
+		t.Errorf("WaitExecution() error = %v, want %v", err, ErrTimeout)
+	}
+}
+
+func TestClient_WaitExecution_canceled(t *testing.T) {
+	c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	_, err := c.WaitExecution(ctx, "DEV", "id", fastWait(0))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("WaitExecution() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestClient_WaitExecution_executionCanceled(t *testing.T) {
+	c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"Canceled","content":{"id":"id"}}`))
+	})
+
+	got, err := c.WaitExecution(context.Background(), "DEV", "id", fastWait(time.Second))
+	if !errors.Is(err, ErrExecutionCanceled) || !got.Canceled() {
+		t.Errorf("WaitExecution() = %+v, %v, want %v", got, err, ErrExecutionCanceled)
+	}
+}
+
+func TestWaitOptions_next(t *testing.T) {
+	opts := WaitOptions{Interval: time.Second, MaxInterval: 3 * time.Second, Multiplier: 2}
+	var got []time.Duration
+	for d := opts.Interval; len(got) < 4; d = opts.next(d) {
+		got = append(got, d)
+	}
+
+	want := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("delays = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestClient_WaitExecution_unauthorized(t *testing.T) {
+	c := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(403)
+	})
+
+	_, err := c.WaitExecution(context.Background(), "DEV", "id", fastWait(time.Second))
+	if !IsUnauthorized(err) {
+		t.Errorf("WaitExecution() error = %v, want an authorization failure", err)
+	}
+}
@@ -0,0 +1,49 @@
+#!/bin/sh
+# Copies lib/dennis into the src of every formula, where its go.mod replaces
+# dennis with ./dennis. rit builds a formula with only its own directory
+# mounted, so the formulas cannot reach lib/dennis itself.
+#
+# Run it after changing lib/dennis. With -check it only reports the copies
+# that are out of date and fails when there is any.
+set -e
+cd "$(dirname "$0")/.."
+
+files() {
+	for f in lib/dennis/*.go; do
+		case "$f" in
+		*_test.go) ;;
+		*) echo "$f" ;;
+		esac
+	done
+	echo lib/dennis/go.mod
+	echo lib/dennis/go.sum
+}
+
+stale=0
+for src in rocket/*/*/src; do
+	dst="$src/dennis"
+	if [ "$1" = "-check" ]; then
+		for f in $(files); do
+			if ! cmp -s "$f" "$dst/$(basename "$f")"; then
+				echo "$dst/$(basename "$f") is out of date"
+				stale=1
+			fi
+		done
+		for f in "$dst"/*; do
+			if [ -e "$f" ] && [ ! -e "lib/dennis/$(basename "$f")" ]; then
+				echo "$f is not in lib/dennis"
+				stale=1
+			fi
+		done
+		continue
+	fi
+
+	rm -rf "$dst"
+	mkdir -p "$dst"
+	cp $(files) "$dst"
+done
+
+if [ "$stale" = 1 ]; then
+	echo "run lib/vendor.sh to update the copies"
+	exit 1
+fi
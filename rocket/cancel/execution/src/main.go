@@ -0,0 +1,23 @@
+package main
+
+import (
+	"dennis"
+	"flag"
+	"os"
+	"rocket/cancel/pkg/cancel"
+)
+
+func main() {
+	id := flag.String("id", dennis.EnvInput("EXECUTION_ID", "pick"), "ID of the execution, picked from the recent ones when empty")
+	context := flag.String("context", dennis.EnvInput("CONTEXT", "pick"), "context of the execution")
+	timeout := flag.Duration("timeout", dennis.EnvDuration("DENNIS_TIMEOUT"), "how long the final state is awaited, 30s by default")
+	flag.Parse()
+
+	cancel.Inputs{
+		Username:    os.Getenv("USERNAME"),
+		Password:    os.Getenv("PASSWORD"),
+		ExecutionID: *id,
+		Context:     *context,
+		Timeout:     *timeout,
+	}.Run()
+}
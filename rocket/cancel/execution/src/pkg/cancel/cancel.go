@@ -0,0 +1,92 @@
+package cancel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"dennis"
+
+	"github.com/ZupIT/ritchie-cli/pkg/prompt"
+)
+
+// defaultTimeout is how long the final state of a canceled execution is
+// awaited.
+const defaultTimeout = 30 * time.Second
+
+type Inputs struct {
+	Username    string
+	Password    string
+	ExecutionID string
+	Context     string
+	Timeout     time.Duration
+}
+
+func (in Inputs) Run() {
+	host, err := dennis.ResolveHost()
+	if err != nil {
+		prompt.Error(err.Error())
+		os.Exit(dennis.ExitCode(err))
+	}
+
+	in.ExecutionID, in.Context, err = dennis.DefaultHistory().SelectExecution(host, in.ExecutionID, in.Context)
+	if err != nil {
+		prompt.Error(err.Error())
+		os.Exit(dennis.ExitCode(err))
+	}
+	client := dennis.NewClient(host, in.Username, in.Password)
+
+	// login
+	prompt.Info("Authenticating...")
+	if err := client.Authenticate(); err != nil {
+		prompt.Error(err.Error())
+		os.Exit(dennis.ExitCode(err))
+	}
+	prompt.Success("done")
+
+	goCtx, stop := dennis.InterruptContext()
+	defer stop()
+
+	execResp, err := in.cancel(goCtx, client)
+	switch {
+	case errors.Is(err, dennis.ErrExecutionCanceled):
+		prompt.Success(fmt.Sprintf("Execution %s canceled", in.ExecutionID))
+		os.Exit(dennis.ExitOK)
+	case errors.Is(err, dennis.ErrTimeout):
+		prompt.Info(fmt.Sprintf("Cancellation requested, but execution %s is still being processed", in.ExecutionID))
+		os.Exit(dennis.ExitRunning)
+	case errors.Is(err, context.Canceled):
+		prompt.Warning(fmt.Sprintf("Interrupted. Cancellation requested, you can check execution %s with the command [rit rocket check execution]", in.ExecutionID))
+		os.Exit(dennis.ExitInterrupted)
+	case err != nil:
+		prompt.Error(err.Error())
+		os.Exit(dennis.ExitCode(err))
+	default:
+		prompt.Warning(fmt.Sprintf("Execution %s finished before being canceled, with status code %d", in.ExecutionID, execResp.Content.StatusCode))
+		os.Exit(dennis.ExitFailure)
+	}
+}
+
+// cancel requests the cancellation of the execution and awaits its final
+// state until goCtx is done. An execution that already finished is not an
+// error.
+func (in Inputs) cancel(goCtx context.Context, client *dennis.Client) (dennis.ExecutionResponse, error) {
+	prompt.Info("Canceling execution...")
+	err := client.CancelExecution(in.Context, in.ExecutionID)
+	if err != nil && !dennis.IsConflict(err) {
+		return dennis.ExecutionResponse{}, err
+	}
+	if err == nil {
+		prompt.Success("done")
+	}
+
+	prompt.Info("Awaiting final state...")
+	opts := dennis.DefaultWaitOptions()
+	opts.Timeout = defaultTimeout
+	if in.Timeout > 0 {
+		opts.Timeout = in.Timeout
+	}
+	return client.WaitExecution(goCtx, in.Context, in.ExecutionID, opts)
+}
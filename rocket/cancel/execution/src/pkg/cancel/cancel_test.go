@@ -0,0 +1,60 @@
+package cancel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"dennis"
+)
+
+func TestInputs_cancel(t *testing.T) {
+	tests := []struct {
+		name         string
+		cancelStatus int
+		status       string
+		interrupted  bool
+		wantErr      error
+		wantNotFound bool
+	}{
+		{name: "canceled", cancelStatus: http.StatusAccepted, status: dennis.StatusCanceled, wantErr: dennis.ErrExecutionCanceled},
+		{name: "already finished", cancelStatus: http.StatusConflict, status: dennis.StatusReady},
+		{name: "still running", cancelStatus: http.StatusAccepted, status: "Running", wantErr: dennis.ErrTimeout},
+		{name: "interrupted", cancelStatus: http.StatusAccepted, status: "Running", interrupted: true, wantErr: context.Canceled},
+		{name: "not found", cancelStatus: http.StatusNotFound, wantNotFound: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/executions/test-07/cancel" {
+					w.WriteHeader(tt.cancelStatus)
+					return
+				}
+				_, _ = w.Write([]byte(`{"status": "` + tt.status + `", "content": {"id": "test-07"}}`))
+			}))
+			defer srv.Close()
+			client := dennis.NewClient(srv.URL, "user", "pass")
+			client.Tokens = dennis.TokenStore{}
+
+			in := Inputs{ExecutionID: "test-07", Context: "DEV", Timeout: 50 * time.Millisecond}
+			goCtx, stop := context.WithCancel(context.Background())
+			if tt.interrupted {
+				stop()
+			}
+			defer stop()
+			_, err := in.cancel(goCtx, client)
+			if tt.wantNotFound {
+				if !dennis.IsNotFound(err) {
+					t.Errorf("cancel() error = %v, want not found", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("cancel() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
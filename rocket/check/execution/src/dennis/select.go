@@ -0,0 +1,82 @@
+package dennis
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ZupIT/ritchie-cli/pkg/prompt"
+)
+
+const (
+	recentQty    = 10
+	typeIDOpt    = "Type an execution ID"
+	recentLayout = "2006-01-02 15:04"
+)
+
+// SelectExecution fills the execution ID and context not given, offering the
+// recent executions submitted from this machine to the same endpoint. The
+// context of a given execution is looked up in the history first, so without
+// a terminal only the ID is needed for the executions found there.
+func (h History) SelectExecution(host, id, ctx string) (string, string, error) {
+	if id != "" && ctx == "" {
+		if e, ok, _ := h.Find(id); ok {
+			ctx = e.Context
+		}
+	}
+	if id != "" && ctx != "" {
+		return id, ctx, nil
+	}
+	if !IsTerminal() {
+		return id, ctx, errors.New("missing execution ID or context, set EXECUTION_ID and CONTEXT")
+	}
+
+	text := prompt.NewSurveyText()
+	if id == "" {
+		recent, err := h.Recent(host, recentQty)
+		if err != nil {
+			prompt.Warning(err.Error())
+		}
+		if e, ok, err := selectRecent(recent); err != nil {
+			return id, ctx, err
+		} else if ok {
+			return e.ID, e.Context, nil
+		}
+
+		if id, err = text.Text("Execution ID: ", true); err != nil {
+			return id, ctx, err
+		}
+	}
+
+	if ctx == "" {
+		var err error
+		if ctx, err = text.Text("Context: ", true); err != nil {
+			return id, ctx, err
+		}
+	}
+	return id, ctx, nil
+}
+
+// selectRecent prompts for one of the recent entries, returning false when
+// there are none or another execution ID is to be typed.
+func selectRecent(recent []HistoryEntry) (HistoryEntry, bool, error) {
+	if len(recent) == 0 {
+		return HistoryEntry{}, false, nil
+	}
+
+	items := make([]string, 0, len(recent)+1)
+	for _, e := range recent {
+		items = append(items, fmt.Sprintf("%s  %s  %s  %s", e.Time.Local().Format(recentLayout), e.Context, e.Command, e.ID))
+	}
+	items = append(items, typeIDOpt)
+
+	choice, err := prompt.NewSurveyList().List("Select a recent execution", items)
+	if err != nil {
+		return HistoryEntry{}, false, err
+	}
+	for i, e := range recent {
+		if items[i] == choice {
+			return e, true, nil
+		}
+	}
+	return HistoryEntry{}, false, nil
+}
@@ -0,0 +1,204 @@
+package dennis
+
+import (
+	"strconv"
+	"time"
+)
+
+type LoginRequest struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// LoginResponse is the token of a login and its expiration. Dennis sends ttl
+// as the unix time the token expires at; expires_at, the same unix time, and
+// expires_in, the seconds from now, take precedence when sent.
+type LoginResponse struct {
+	Token     string `json:"token,omitempty"`
+	TTL       int64  `json:"ttl,omitempty"`
+	ExpiresAt int64  `json:"expires_at,omitempty"`
+	ExpiresIn int64  `json:"expires_in,omitempty"`
+}
+
+// Expiry returns the unix time the token expires at.
+func (r LoginResponse) Expiry(now time.Time) int64 {
+	switch {
+	case r.ExpiresAt > 0:
+		return r.ExpiresAt
+	case r.ExpiresIn > 0:
+		return now.Unix() + r.ExpiresIn
+	default:
+		return r.TTL
+	}
+}
+
+type FormulasResponse struct {
+	Contexts Contexts `json:"contexts,omitempty"`
+	Formulas Formulas `json:"formulas,omitempty"`
+}
+
+type Context struct {
+	Name string `json:"name,omitempty"`
+}
+
+type Contexts []Context
+
+// Names returns the name of every context, in the order sent by the server.
+func (c Contexts) Names() []string {
+	names := make([]string, len(c))
+	for i, ctx := range c {
+		names[i] = ctx.Name
+	}
+	return names
+}
+
+type Formula struct {
+	Command string `json:"command,omitempty"`
+	Inputs  Inputs `json:"inputs,omitempty"`
+}
+
+type Formulas []Formula
+
+// Commands returns the command of every formula, in the order sent by the server.
+func (f Formulas) Commands() []string {
+	commands := make([]string, len(f))
+	for i, form := range f {
+		commands[i] = form.Command
+	}
+	return commands
+}
+
+// Find returns the formula with the given command.
+func (f Formulas) Find(command string) (Formula, bool) {
+	for _, form := range f {
+		if form.Command == command {
+			return form, true
+		}
+	}
+	return Formula{}, false
+}
+
+type Input struct {
+	Name      string     `json:"name,omitempty"`
+	Label     string     `json:"label,omitempty"`
+	Type      string     `json:"type,omitempty"`
+	Items     Items      `json:"items,omitempty"`
+	Default   string     `json:"default,omitempty"`
+	Cache     *Cache     `json:"cache,omitempty"`
+	Required  bool       `json:"required,omitempty"`
+	Pattern   *Pattern   `json:"pattern,omitempty"`
+	Min       *float64   `json:"min,omitempty"`
+	Max       *float64   `json:"max,omitempty"`
+	Condition *Condition `json:"condition,omitempty"`
+	Value     string     `json:"value,omitempty"`
+}
+
+// Cache tells to offer the last Qty values of an input, along with NewLabel
+// to type a new one. The items of a cached input are only suggestions.
+type Cache struct {
+	Active   bool   `json:"active,omitempty"`
+	Qty      int    `json:"qty,omitempty"`
+	NewLabel string `json:"newLabel,omitempty"`
+}
+
+// Pattern restricts the values of an input to the ones matching Regex, with
+// MismatchText telling the user what is expected otherwise.
+type Pattern struct {
+	Regex        string `json:"regex,omitempty"`
+	MismatchText string `json:"mismatchText,omitempty"`
+}
+
+// Cached reports whether the recent values of the input are offered.
+func (i Input) Cached() bool {
+	return i.Cache != nil && i.Cache.Active
+}
+
+type Items []string
+
+type Inputs []Input
+
+// Find returns the input with the given name.
+func (in Inputs) Find(name string) (Input, bool) {
+	for _, input := range in {
+		if input.Name == name {
+			return input, true
+		}
+	}
+	return Input{}, false
+}
+
+type CommandRequest struct {
+	ID       string    `json:"id,omitempty"`
+	Command  string    `json:"command,omitempty"`
+	Inputs   Inputs    `json:"inputs,omitempty"`
+	Metadata *Metadata `json:"metadata,omitempty"`
+}
+
+type CredentialRequest struct {
+	Service    string            `json:"service,omitempty"`
+	Credential map[string]string `json:"credential"`
+}
+
+type ExecutionResponse struct {
+	Status  string  `json:"status,omitempty"`
+	Content Content `json:"content,omitempty"`
+}
+
+// Ready reports whether the remote formula has finished.
+func (e ExecutionResponse) Ready() bool {
+	return e.Status == StatusReady
+}
+
+// Canceled reports whether the remote formula was aborted before finishing.
+func (e ExecutionResponse) Canceled() bool {
+	return e.Status == StatusCanceled
+}
+
+type Content struct {
+	ID            string   `json:"id,omitempty"`
+	Command       string   `json:"command,omitempty"`
+	StatusCode    int      `json:"statusCode,omitempty"`
+	User          string   `json:"user,omitempty"`
+	StartTime     ExecTime `json:"startTime,omitempty"`
+	EndTime       ExecTime `json:"endTime,omitempty"`
+	FormulaErr    string   `json:"formulaErr,omitempty"`
+	FormulaOut    string   `json:"formulaOutput,omitempty"`
+	FormulaInputs Inputs   `json:"formulaInputs,omitempty"`
+}
+
+// Duration returns how long the remote formula took to run.
+func (c Content) Duration() time.Duration {
+	return c.EndTime.Sub(c.StartTime)
+}
+
+type ExecTime time.Time
+
+func (t ExecTime) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(time.Time(t).Unix(), 10)), nil
+}
+
+func (t *ExecTime) UnmarshalJSON(s []byte) (err error) {
+	r := string(s)
+	q, err := strconv.ParseInt(r, 10, 64)
+	if err != nil {
+		return err
+	}
+	*(*time.Time)(t) = time.Unix(q, 0)
+	return nil
+}
+
+func (t ExecTime) Unix() int64 {
+	return time.Time(t).Unix()
+}
+
+func (t ExecTime) Time() time.Time {
+	return time.Time(t).UTC()
+}
+
+func (t ExecTime) String() string {
+	return t.Time().String()
+}
+
+func (t ExecTime) Sub(o ExecTime) time.Duration {
+	return time.Time(t).Sub(time.Time(o))
+}
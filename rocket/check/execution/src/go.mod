@@ -3,6 +3,8 @@ module hello
 go 1.14
 
 require (
+	dennis v0.0.0-00010101000000-000000000000
 	github.com/ZupIT/ritchie-cli v0.0.0-20200806162951-cd8acdae49af
-	github.com/gookit/color v1.2.5
 )
+
+replace dennis => ./dennis
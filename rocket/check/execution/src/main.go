@@ -1,16 +1,27 @@
 package main
 
 import (
+	"dennis"
+	"flag"
 	"hello/pkg/hello"
 	"os"
 )
 
 func main() {
+	id := flag.String("id", dennis.EnvInput("EXECUTION_ID", "pick"), "ID of the execution, picked from the recent ones when empty")
+	context := flag.String("context", dennis.EnvInput("CONTEXT", "pick"), "context of the execution")
+	output := flag.String("output", dennis.EnvString("DENNIS_OUTPUT", "text"), "format of the result: text, json, yaml or table")
+	follow := flag.Bool("follow", os.Getenv("DENNIS_FOLLOW") == "true", "keep polling until the execution is ready")
+	timeout := flag.Duration("timeout", dennis.EnvDuration("DENNIS_TIMEOUT"), "how long the execution is followed, e.g. 10m, no limit by default")
+	flag.Parse()
 
 	hello.Inputs{
 		Username:    os.Getenv("USERNAME"),
 		Password:    os.Getenv("PASSWORD"),
-		ExecutionID: os.Getenv("EXECUTION_ID"),
-		Context:     os.Getenv("CONTEXT"),
+		ExecutionID: *id,
+		Context:     *context,
+		Output:      *output,
+		Follow:      *follow,
+		Timeout:     *timeout,
 	}.Run()
 }
@@ -0,0 +1,71 @@
+package hello
+
+import (
+	"fmt"
+	"time"
+
+	"dennis"
+
+	"github.com/ZupIT/ritchie-cli/pkg/prompt"
+)
+
+// stillEvery is how often an unchanged status is reported while following.
+const stillEvery = time.Minute
+
+// follow polls the execution until it is ready, the timeout expires or Ctrl-C
+// is pressed, reporting every status transition with the elapsed time and
+// printing the output as it arrives. It reports whether the output was
+// streamed.
+func (in Inputs) follow(client *dennis.Client) (dennis.ExecutionResponse, bool, error) {
+	goCtx, stop := dennis.InterruptContext()
+	defer stop()
+
+	if in.Timeout > 0 {
+		prompt.Info(fmt.Sprintf("Following execution %s for up to %s...", in.ExecutionID, in.Timeout))
+	} else {
+		prompt.Info(fmt.Sprintf("Following execution %s, Ctrl-C to stop...", in.ExecutionID))
+	}
+
+	logs := &dennis.LogTail{Client: client, Context: in.Context, ID: in.ExecutionID}
+	start := time.Now()
+	status, reported := "", start
+	opts := dennis.DefaultWaitOptions()
+	opts.Timeout = in.Timeout
+	opts.OnPoll = func(resp dennis.ExecutionResponse, err error) {
+		current := pollStatus(resp, err)
+		elapsed := time.Since(start).Round(time.Second)
+		switch {
+		case current != status:
+			fmt.Printf("[%s] %s\n", elapsed, current)
+			status, reported = current, time.Now()
+		case time.Since(reported) >= stillEvery:
+			fmt.Printf("[%s] still %s\n", elapsed, current)
+			reported = time.Now()
+		}
+		if err != nil || !resp.Ready() {
+			logs.Print(logs.Poll(goCtx, err))
+		}
+	}
+
+	execResp, err := client.WaitExecution(goCtx, in.Context, in.ExecutionID, opts)
+	if err == nil {
+		// the output written between the last poll and the end
+		logs.Print(logs.Poll(goCtx, nil))
+	}
+	return execResp, logs.Streamed(), err
+}
+
+// pollStatus describes the outcome of a poll. An execution that is not found
+// yet is taken as queued.
+func pollStatus(resp dennis.ExecutionResponse, err error) string {
+	switch {
+	case dennis.IsNotFound(err):
+		return "Queued"
+	case err != nil:
+		return "Error: " + err.Error()
+	case resp.Status == "":
+		return "Unknown"
+	default:
+		return resp.Status
+	}
+}
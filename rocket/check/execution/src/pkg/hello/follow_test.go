@@ -0,0 +1,69 @@
+package hello
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"dennis"
+)
+
+func TestPollStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		resp dennis.ExecutionResponse
+		err  error
+		want string
+	}{
+		{name: "queued", err: &dennis.StatusError{Status: 404, Msg: "execution not found"}, want: "Queued"},
+		{name: "error", err: errors.New("connection refused"), want: "Error: connection refused"},
+		{name: "no status", want: "Unknown"},
+		{name: "running", resp: dennis.ExecutionResponse{Status: "Running"}, want: "Running"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pollStatus(tt.resp, tt.err); got != tt.want {
+				t.Errorf("pollStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInputs_follow(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       string
+		logs         bool
+		wantStreamed bool
+		wantErr      error
+	}{
+		{name: "ready with logs", status: dennis.StatusReady, logs: true, wantStreamed: true},
+		{name: "ready without logs", status: dennis.StatusReady},
+		{name: "canceled", status: dennis.StatusCanceled, wantErr: dennis.ErrExecutionCanceled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/logs") && tt.logs:
+					_, _ = w.Write([]byte(`{"offset": 7, "chunks": [{"stream": "stdout", "data": "coffee\n"}], "done": true}`))
+				case strings.HasSuffix(r.URL.Path, "/logs"):
+					w.WriteHeader(http.StatusNotImplemented)
+				default:
+					_, _ = w.Write([]byte(`{"status": "` + tt.status + `", "content": {"id": "test-07"}}`))
+				}
+			}))
+			defer srv.Close()
+			client := dennis.NewClient(srv.URL, "user", "pass")
+			client.Tokens = dennis.TokenStore{}
+
+			in := Inputs{ExecutionID: "test-07", Context: "DEV"}
+			_, streamed, err := in.follow(client)
+			if !errors.Is(err, tt.wantErr) || streamed != tt.wantStreamed {
+				t.Errorf("follow() = %v, %v, want %v, %v", streamed, err, tt.wantStreamed, tt.wantErr)
+			}
+		})
+	}
+}
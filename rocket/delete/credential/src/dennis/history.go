@@ -0,0 +1,152 @@
+package dennis
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// redacted replaces the value of secret inputs in the history.
+const redacted = "******"
+
+// HistoryEntry is a command submitted from this machine.
+type HistoryEntry struct {
+	ID       string            `json:"id"`
+	Context  string            `json:"context"`
+	Command  string            `json:"command"`
+	Inputs   map[string]string `json:"inputs,omitempty"`
+	Time     time.Time         `json:"time"`
+	Endpoint string            `json:"endpoint"`
+}
+
+// NewHistoryEntry returns the entry of a command sent to host, with the
+// password and credential inputs of the formula redacted.
+func NewHistoryEntry(host, id string, inv Invocation, form Formula) HistoryEntry {
+	inputs := make(map[string]string, len(inv.Inputs))
+	for name, value := range inv.Inputs {
+		if input, ok := form.Inputs.Find(name); ok && input.secret() {
+			value = redacted
+		}
+		inputs[name] = value
+	}
+
+	return HistoryEntry{
+		ID:       id,
+		Context:  inv.Context,
+		Command:  inv.Command,
+		Inputs:   inputs,
+		Time:     time.Now().UTC(),
+		Endpoint: host,
+	}
+}
+
+// History is an append-only journal of the submitted commands, a JSON entry
+// per line. A history with an empty Path records nothing.
+type History struct {
+	Path string
+}
+
+// DefaultHistory returns the history kept in ~/.rit/rocket/history.jsonl.
+func DefaultHistory() History {
+	dir, err := Dir()
+	if err != nil {
+		return History{}
+	}
+	return History{Path: filepath.Join(dir, "history.jsonl")}
+}
+
+// Append records an entry at the end of the history.
+func (h History) Append(e HistoryEntry) error {
+	if h.Path == "" {
+		return nil
+	}
+
+	b, err := json.Marshal(&e)
+	if err != nil {
+		return fmt.Errorf("error encoding history entry: %w", err)
+	}
+	if err = os.MkdirAll(filepath.Dir(h.Path), 0700); err != nil {
+		return fmt.Errorf("error creating history dir: %w", err)
+	}
+
+	f, err := os.OpenFile(h.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("error opening history: %w", err)
+	}
+	defer f.Close()
+
+	// a single write per entry keeps concurrent appends on their own lines
+	if _, err = f.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("error writing history: %w", err)
+	}
+	return nil
+}
+
+// Recent returns up to n entries sent to host, newest first. An empty host
+// returns the entries of every endpoint and n <= 0 returns all of them.
+// Malformed lines are skipped.
+func (h History) Recent(host string, n int) ([]HistoryEntry, error) {
+	entries, err := h.load()
+	if err != nil {
+		return nil, err
+	}
+
+	var recent []HistoryEntry
+	for i := len(entries) - 1; i >= 0 && (n <= 0 || len(recent) < n); i-- {
+		if host == "" || entries[i].Endpoint == host {
+			recent = append(recent, entries[i])
+		}
+	}
+	return recent, nil
+}
+
+// Find returns the newest entry with the given execution ID.
+func (h History) Find(id string) (HistoryEntry, bool, error) {
+	entries, err := h.Recent("", 0)
+	if err != nil {
+		return HistoryEntry{}, false, err
+	}
+	for _, e := range entries {
+		if e.ID == id {
+			return e, true, nil
+		}
+	}
+	return HistoryEntry{}, false, nil
+}
+
+func (h History) load() ([]HistoryEntry, error) {
+	if h.Path == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(h.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading history: %w", err)
+	}
+	defer f.Close()
+
+	var entries []HistoryEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		e := HistoryEntry{}
+		if err := json.Unmarshal(scanner.Bytes(), &e); err == nil && e.ID != "" {
+			entries = append(entries, e)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading history: %w", err)
+	}
+	return entries, nil
+}
+
+// secret reports whether the value of the input must not be stored.
+func (i Input) secret() bool {
+	return i.Type == "password" || IsCredentialType(i.Type)
+}
@@ -0,0 +1,216 @@
+package dennis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Invocation describes one execution of a remote formula, so it can be
+// checked into git and replayed.
+type Invocation struct {
+	Context string            `json:"context,omitempty" yaml:"context,omitempty"`
+	Command string            `json:"command,omitempty" yaml:"command,omitempty"`
+	Inputs  map[string]string `json:"inputs,omitempty" yaml:"inputs,omitempty"`
+}
+
+// ValidationError lists every problem found in an invocation.
+type ValidationError []string
+
+func (e ValidationError) Error() string {
+	return "invalid invocation:\n  - " + strings.Join(e, "\n  - ")
+}
+
+// LoadInvocation reads an invocation file, YAML when its extension is .yml or
+// .yaml and JSON otherwise.
+func LoadInvocation(path string) (Invocation, error) {
+	inv := Invocation{}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return inv, fmt.Errorf("error reading invocation file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yml", ".yaml":
+		err = yaml.Unmarshal(b, &inv)
+	default:
+		err = json.Unmarshal(b, &inv)
+	}
+	if err != nil {
+		return inv, fmt.Errorf("error decoding invocation file: %w", err)
+	}
+	return inv, nil
+}
+
+// Validate checks the invocation against the contexts and the input schema
+// of the formulas served by Dennis.
+func (inv Invocation) Validate(resp FormulasResponse) error {
+	var errs ValidationError
+	if inv.Context == "" {
+		errs = append(errs, "missing context")
+	} else if !contains(resp.Contexts.Names(), inv.Context) {
+		errs = append(errs, fmt.Sprintf("context %q not found", inv.Context))
+	}
+
+	if inv.Command == "" {
+		errs = append(errs, "missing command")
+		return errs
+	}
+	form, ok := resp.Formulas.Find(inv.Command)
+	if !ok {
+		errs = append(errs, fmt.Sprintf("formula %q not found", inv.Command))
+		return errs
+	}
+
+	names := make([]string, 0, len(inv.Inputs))
+	for name := range inv.Inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := form.Inputs.Find(name); !ok {
+			errs = append(errs, fmt.Sprintf("unknown input %q", name))
+		}
+	}
+
+	active := form.Inputs.Resolve(inv.Inputs)
+	for i, input := range form.Inputs {
+		errs = append(errs, input.Condition.check(input.Name, form.Inputs[:i])...)
+		if _, ok := active[input.Name]; !ok {
+			continue
+		}
+
+		value, ok := inv.Inputs[input.Name]
+		if !ok {
+			if input.Default == "" {
+				errs = append(errs, fmt.Sprintf("missing input %q", input.Name))
+			}
+			continue
+		}
+		errs = append(errs, input.check(value)...)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// CommandRequest builds the command of the invocation, with the inputs in the
+// order of the formula schema and the missing ones taking their default.
+// Inputs whose condition does not hold are left out.
+func (inv Invocation) CommandRequest(id string, form Formula) CommandRequest {
+	values := form.Inputs.Resolve(inv.Inputs)
+	inputs := make(Inputs, 0, len(values))
+	for _, input := range form.Inputs {
+		if value, ok := values[input.Name]; ok {
+			inputs = append(inputs, Input{Name: input.Name, Type: input.Type, Value: value})
+		}
+	}
+
+	return CommandRequest{
+		ID:      id,
+		Command: form.Command,
+		Inputs:  inputs,
+	}
+}
+
+// DateLayout is the format of the values of date inputs.
+const DateLayout = "2006-01-02"
+
+// Check validates a value against the rules of the input, reporting every
+// problem found.
+func (i Input) Check(value string) error {
+	if problems := i.check(value); len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// check validates a value against the type, the items, the pattern and the
+// bounds of the input. The bounds limit number and integer values and the
+// length of the other ones.
+func (i Input) check(value string) []string {
+	if value == "" {
+		if i.Required {
+			return []string{fmt.Sprintf("input %q is required", i.Name)}
+		}
+		return nil
+	}
+
+	var problems []string
+	n := float64(utf8.RuneCountInString(value))
+	switch i.Type {
+	case "bool":
+		if value != "true" && value != "false" {
+			problems = append(problems, fmt.Sprintf("input %q must be true or false", i.Name))
+		}
+	case "text":
+		if len(i.Items) > 0 && !i.Cached() && !contains(i.Items, value) {
+			problems = append(problems, fmt.Sprintf("input %q must be one of %s", i.Name, strings.Join(i.Items, ", ")))
+		}
+	case "number":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return []string{fmt.Sprintf("input %q must be a number", i.Name)}
+		}
+		n = f
+	case "integer":
+		d, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return []string{fmt.Sprintf("input %q must be an integer", i.Name)}
+		}
+		n = float64(d)
+	case "date":
+		if _, err := time.Parse(DateLayout, value); err != nil {
+			problems = append(problems, fmt.Sprintf("input %q must be a date as YYYY-MM-DD", i.Name))
+		}
+	case "path":
+		if strings.ContainsAny(value, "\x00\n") {
+			problems = append(problems, fmt.Sprintf("input %q must be a path", i.Name))
+		}
+	}
+
+	if i.Pattern != nil && i.Pattern.Regex != "" {
+		re, err := regexp.Compile(i.Pattern.Regex)
+		switch {
+		case err != nil:
+			problems = append(problems, fmt.Sprintf("input %q has the invalid pattern %q", i.Name, i.Pattern.Regex))
+		case !re.MatchString(value) && i.Pattern.MismatchText != "":
+			problems = append(problems, fmt.Sprintf("input %q: %s", i.Name, i.Pattern.MismatchText))
+		case !re.MatchString(value):
+			problems = append(problems, fmt.Sprintf("input %q must match %s", i.Name, i.Pattern.Regex))
+		}
+	}
+
+	unit := ""
+	if i.Type != "number" && i.Type != "integer" {
+		unit = " characters"
+	}
+	if i.Min != nil && n < *i.Min {
+		problems = append(problems, fmt.Sprintf("input %q must be at least %v%s", i.Name, *i.Min, unit))
+	}
+	if i.Max != nil && n > *i.Max {
+		problems = append(problems, fmt.Sprintf("input %q must be at most %v%s", i.Name, *i.Max, unit))
+	}
+	return problems
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package dennis
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// credentialPrefix starts the type of inputs resolved from the credentials
+// set with rit, e.g. CREDENTIAL_AWS_ACCESSKEYID.
+const credentialPrefix = "CREDENTIAL_"
+
+// defaultRitContext is the rit context used when none is set.
+const defaultRitContext = "default"
+
+// IsCredentialType reports whether an input type is CREDENTIAL_<PROVIDER>_<FIELD>.
+func IsCredentialType(t string) bool {
+	_, _, ok := ParseCredentialType(t)
+	return ok
+}
+
+// ParseCredentialType returns the provider and the field of a
+// CREDENTIAL_<PROVIDER>_<FIELD> input type, in lowercase.
+func ParseCredentialType(t string) (provider, field string, ok bool) {
+	if !strings.HasPrefix(t, credentialPrefix) {
+		return "", "", false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(t, credentialPrefix), "_", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return strings.ToLower(parts[0]), strings.ToLower(parts[1]), true
+}
+
+// LocalCredentials reads the credentials set with rit set credential, kept in
+// <Home>/credentials/<rit context>/<provider>. A store with an empty Home
+// has no credentials.
+type LocalCredentials struct {
+	Home string
+}
+
+type localCredential struct {
+	Service    string            `json:"service"`
+	Credential map[string]string `json:"credential"`
+}
+
+// DefaultLocalCredentials returns the credentials of ~/.rit.
+func DefaultLocalCredentials() LocalCredentials {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return LocalCredentials{}
+	}
+	return LocalCredentials{Home: filepath.Join(home, ".rit")}
+}
+
+// Get returns the field of the provider credential of the current rit context.
+func (l LocalCredentials) Get(provider, field string) (string, bool) {
+	if l.Home == "" {
+		return "", false
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(l.Home, "credentials", l.context(), provider))
+	if err != nil {
+		return "", false
+	}
+	cred := localCredential{}
+	if err := json.Unmarshal(b, &cred); err != nil {
+		return "", false
+	}
+
+	value, ok := cred.Credential[field]
+	return value, ok && value != ""
+}
+
+// Resolve returns the value of a CREDENTIAL_<PROVIDER>_<FIELD> input type.
+func (l LocalCredentials) Resolve(t string) (string, bool) {
+	provider, field, ok := ParseCredentialType(t)
+	if !ok {
+		return "", false
+	}
+	return l.Get(provider, field)
+}
+
+// context returns the current rit context, kept in <Home>/contexts.
+func (l LocalCredentials) context() string {
+	ctx := struct {
+		Current string `json:"current_context"`
+	}{}
+	b, err := ioutil.ReadFile(filepath.Join(l.Home, "contexts"))
+	if err != nil || json.Unmarshal(b, &ctx) != nil || ctx.Current == "" {
+		return defaultRitContext
+	}
+	return ctx.Current
+}
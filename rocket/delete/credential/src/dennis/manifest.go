@@ -0,0 +1,75 @@
+package dennis
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Manifest lists the invocations of a batch run. Parallel bounds how many of
+// them run at the same time, 1 running them in sequence, and Timeout is how
+// long each execution is awaited, e.g. "5m".
+type Manifest struct {
+	Parallel    int          `json:"parallel,omitempty" yaml:"parallel,omitempty"`
+	Timeout     string       `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Invocations []Invocation `json:"invocations" yaml:"invocations"`
+}
+
+// LoadManifest reads a manifest file, YAML when its extension is .yml or .yaml
+// and JSON otherwise.
+func LoadManifest(path string) (Manifest, error) {
+	m := Manifest{}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return m, fmt.Errorf("error reading manifest: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yml", ".yaml":
+		err = yaml.Unmarshal(b, &m)
+	default:
+		err = json.Unmarshal(b, &m)
+	}
+	if err != nil {
+		return m, fmt.Errorf("error decoding manifest: %w", err)
+	}
+	return m, nil
+}
+
+// TimeoutDuration parses Timeout, falling back to def when it is not set.
+func (m Manifest) TimeoutDuration(def time.Duration) (time.Duration, error) {
+	if m.Timeout == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(m.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid manifest timeout: %w", err)
+	}
+	return d, nil
+}
+
+// Validate checks every invocation of the manifest, prefixing the problems
+// with the position of the invocation.
+func (m Manifest) Validate(resp FormulasResponse) error {
+	var errs ValidationError
+	if len(m.Invocations) == 0 {
+		errs = append(errs, "manifest without invocations")
+	}
+	for i, inv := range m.Invocations {
+		if err := inv.Validate(resp); err != nil {
+			for _, e := range err.(ValidationError) {
+				errs = append(errs, fmt.Sprintf("invocation %d: %s", i+1, e))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package dennis
+
+import (
+	"net"
+	"os"
+	"runtime"
+)
+
+// Version is the version of the rocket formulas sent along with every
+// command. It can be set at build time with -ldflags "-X dennis.Version=...".
+var Version = "1.0.0"
+
+// Metadata describes where a command was sent from. Fields that cannot be
+// determined are left empty.
+type Metadata struct {
+	IPAddr        string `json:"ipAddr,omitempty"`
+	Hostname      string `json:"hostname,omitempty"`
+	OS            string `json:"os,omitempty"`
+	ClientVersion string `json:"clientVersion,omitempty"`
+	RitVersion    string `json:"ritVersion,omitempty"`
+}
+
+// LocalMetadata returns the metadata of this machine. The rit version is
+// taken from the RIT_VERSION env var.
+func LocalMetadata() Metadata {
+	hostname, _ := os.Hostname()
+	return Metadata{
+		IPAddr:        LocalAddr(),
+		Hostname:      hostname,
+		OS:            runtime.GOOS + "/" + runtime.GOARCH,
+		ClientVersion: Version,
+		RitVersion:    os.Getenv("RIT_VERSION"),
+	}
+}
+
+// LocalAddr returns the IP address of the interface holding the default
+// route or, without one, the first IPv4 address that is not a loopback. It
+// returns an empty string when there is no network.
+func LocalAddr() string {
+	// no packet is sent, dialing UDP only picks the outbound interface
+	if conn, err := net.Dial("udp", "8.8.8.8:80"); err == nil {
+		defer conn.Close()
+		if addr, ok := conn.LocalAddr().(*net.UDPAddr); ok {
+			return addr.IP.String()
+		}
+	}
+
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return ""
+	}
+	for _, addr := range addrs {
+		if ip, ok := addr.(*net.IPNet); ok && !ip.IP.IsLoopback() && ip.IP.To4() != nil {
+			return ip.IP.String()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,115 @@
+package dennis
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// expiryMargin discards cached tokens that are about to expire, so a request
+// does not start with a token that dies in flight.
+const expiryMargin = 30 * time.Second
+
+// TokenStore persists login tokens per user and endpoint in a JSON file. A
+// store with an empty Path caches nothing.
+type TokenStore struct {
+	Path string
+}
+
+type cachedToken struct {
+	Token     string `json:"token"`
+	ExpiresAt int64  `json:"expiresAt"`
+}
+
+// DefaultTokenStore returns the store kept in ~/.rit/rocket/tokens.json.
+func DefaultTokenStore() TokenStore {
+	dir, err := Dir()
+	if err != nil {
+		return TokenStore{}
+	}
+	return TokenStore{Path: filepath.Join(dir, "tokens.json")}
+}
+
+// Get returns the token cached for the user at host, if it is still valid.
+func (s TokenStore) Get(host, username string) (string, bool) {
+	tokens, err := s.load()
+	if err != nil {
+		return "", false
+	}
+
+	t, ok := tokens[tokenKey(host, username)]
+	if !ok || time.Now().Add(expiryMargin).Unix() >= t.ExpiresAt {
+		return "", false
+	}
+	return t.Token, true
+}
+
+// Put caches the token returned by a login until its expiry.
+func (s TokenStore) Put(host, username string, resp LoginResponse) error {
+	if s.Path == "" {
+		return nil
+	}
+	expiresAt := resp.Expiry(time.Now())
+
+	tokens, err := s.load()
+	if err != nil {
+		tokens = map[string]cachedToken{}
+	}
+	tokens[tokenKey(host, username)] = cachedToken{Token: resp.Token, ExpiresAt: expiresAt}
+	return s.save(tokens)
+}
+
+// Delete removes the token cached for the user at host.
+func (s TokenStore) Delete(host, username string) error {
+	if s.Path == "" {
+		return nil
+	}
+
+	tokens, err := s.load()
+	if err != nil {
+		return nil
+	}
+	delete(tokens, tokenKey(host, username))
+	return s.save(tokens)
+}
+
+func (s TokenStore) load() (map[string]cachedToken, error) {
+	tokens := map[string]cachedToken{}
+	if s.Path == "" {
+		return tokens, nil
+	}
+
+	b, err := ioutil.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return tokens, nil
+	}
+	if err != nil {
+		return tokens, fmt.Errorf("error reading token cache: %w", err)
+	}
+
+	if err = json.Unmarshal(b, &tokens); err != nil {
+		return tokens, fmt.Errorf("error decoding token cache: %w", err)
+	}
+	return tokens, nil
+}
+
+func (s TokenStore) save(tokens map[string]cachedToken) error {
+	b, err := json.Marshal(&tokens)
+	if err != nil {
+		return fmt.Errorf("error encoding token cache: %w", err)
+	}
+	if err = os.MkdirAll(filepath.Dir(s.Path), 0700); err != nil {
+		return fmt.Errorf("error creating token cache dir: %w", err)
+	}
+	if err = ioutil.WriteFile(s.Path, b, 0600); err != nil {
+		return fmt.Errorf("error writing token cache: %w", err)
+	}
+	return nil
+}
+
+func tokenKey(host, username string) string {
+	return username + "@" + host
+}
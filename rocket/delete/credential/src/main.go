@@ -0,0 +1,23 @@
+package main
+
+import (
+	"dennis"
+	"flag"
+	"os"
+	"rocket/credential/pkg/credential"
+)
+
+func main() {
+	context := flag.String("context", dennis.EnvInput("DENNIS_CONTEXT", "pick"), "context of the credential, picked from the contexts when empty")
+	provider := flag.String("provider", dennis.EnvInput("DENNIS_PROVIDER", "pick"), "provider of the credential, picked from the ones set when empty")
+	yes := flag.Bool("yes", os.Getenv("DENNIS_YES") == "true", "delete without asking for confirmation")
+	flag.Parse()
+
+	credential.Inputs{
+		Username: os.Getenv("USERNAME"),
+		Password: os.Getenv("PASSWORD"),
+		Context:  *context,
+		Provider: *provider,
+		Yes:      *yes,
+	}.Run()
+}
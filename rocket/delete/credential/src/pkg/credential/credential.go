@@ -0,0 +1,109 @@
+package credential
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"dennis"
+
+	"github.com/ZupIT/ritchie-cli/pkg/prompt"
+)
+
+type Inputs struct {
+	Username string
+	Password string
+	Context  string
+	Provider string
+	Yes      bool
+}
+
+func (in Inputs) Run() {
+	interactive := dennis.IsTerminal()
+	if !interactive && (in.Context == "" || in.Provider == "" || !in.Yes) {
+		prompt.Error("without a terminal, set the credential to delete with -context, -provider and -yes")
+		os.Exit(dennis.ExitFailure)
+	}
+
+	host, err := dennis.ResolveHost()
+	if err != nil {
+		prompt.Error(err.Error())
+		os.Exit(dennis.ExitCode(err))
+	}
+	client := dennis.NewClient(host, in.Username, in.Password)
+
+	// login
+	prompt.Info("Authenticating...")
+	if err := client.Authenticate(); err != nil {
+		prompt.Error(err.Error())
+		os.Exit(dennis.ExitCode(err))
+	}
+	prompt.Success("done")
+
+	in, err = in.selectCredential(client)
+	if err != nil {
+		prompt.Error(err.Error())
+		os.Exit(dennis.ExitCode(err))
+	}
+
+	if !in.Yes {
+		label := fmt.Sprintf("Delete the %s credential of %s?", in.Provider, in.Context)
+		ok, err := prompt.NewSurveyBool().Bool(label, []string{"no", "yes"})
+		if err != nil {
+			prompt.Error(err.Error())
+			os.Exit(dennis.ExitFailure)
+		}
+		if !ok {
+			prompt.Info("Nothing deleted")
+			return
+		}
+	}
+
+	prompt.Info("Deleting credential...")
+	if err := client.DeleteCredential(in.Context, in.Provider); err != nil {
+		prompt.Error(err.Error())
+		os.Exit(dennis.ExitCode(err))
+	}
+	prompt.Success("done")
+}
+
+// selectCredential prompts for the context and the provider not given, the
+// provider among the credentials set in the context.
+func (in Inputs) selectCredential(client *dennis.Client) (Inputs, error) {
+	list := prompt.NewSurveyList()
+	if in.Context == "" {
+		prompt.Info("Obtaining contexts...")
+		formulasResp, err := client.ListFormulas()
+		if err != nil {
+			return in, err
+		}
+		prompt.Success("done")
+
+		in.Context, err = list.List("Select a context", formulasResp.Contexts.Names())
+		if err != nil {
+			return in, err
+		}
+	}
+
+	if in.Provider == "" {
+		prompt.Info("Listing credentials...")
+		resp, err := client.ListCredentials(in.Context)
+		if err != nil {
+			return in, err
+		}
+		prompt.Success("done")
+		if len(resp.Credentials) == 0 {
+			return in, errors.New("no credentials set in context " + in.Context)
+		}
+
+		providers := make([]string, len(resp.Credentials))
+		for i, info := range resp.Credentials {
+			providers[i] = info.Service
+		}
+		in.Provider, err = list.List("Select a credential", providers)
+		if err != nil {
+			return in, err
+		}
+	}
+	return in, nil
+}
@@ -0,0 +1,10 @@
+module dennis
+
+go 1.14
+
+require (
+	github.com/ZupIT/ritchie-cli v0.0.0-20200806162951-cd8acdae49af
+	github.com/gookit/color v1.2.5
+	github.com/mattn/go-isatty v0.0.11
+	gopkg.in/yaml.v2 v2.3.0
+)
@@ -0,0 +1,306 @@
+package dennis
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ImportedCredential is the credential of a provider found in the config of
+// another tool, e.g. a profile of ~/.aws/credentials.
+type ImportedCredential struct {
+	Source  string
+	Profile string
+	Fields  map[string]string
+}
+
+func (c ImportedCredential) String() string {
+	return fmt.Sprintf("%s of %s", c.Profile, c.Source)
+}
+
+// LocalSources are the config files of other tools credentials are imported
+// from. Missing files have no credentials.
+type LocalSources struct {
+	AWSCredentials string
+	GHHosts        string
+	Kubeconfig     string
+	Netrc          string
+}
+
+// DefaultLocalSources returns the config files of the user, honoring the env
+// vars the tools themselves use to move them.
+func DefaultLocalSources() LocalSources {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return LocalSources{}
+	}
+
+	ghDir := filepath.Join(home, ".config", "gh")
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		ghDir = filepath.Join(dir, "gh")
+	}
+	kubeconfig := filepath.Join(home, ".kube", "config")
+	if list := filepath.SplitList(os.Getenv("KUBECONFIG")); len(list) > 0 && list[0] != "" {
+		kubeconfig = list[0]
+	}
+	return LocalSources{
+		AWSCredentials: EnvString("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(home, ".aws", "credentials")),
+		GHHosts:        filepath.Join(EnvString("GH_CONFIG_DIR", ghDir), "hosts.yml"),
+		Kubeconfig:     kubeconfig,
+		Netrc:          EnvString("NETRC", filepath.Join(home, ".netrc")),
+	}
+}
+
+// Find returns the credentials of the provider found in every source, in the
+// fields of its declaration.
+func (s LocalSources) Find(provider string) []ImportedCredential {
+	switch provider {
+	case "aws":
+		return s.aws()
+	case "github":
+		return append(s.gh(), s.netrc("github")...)
+	case "gitlab":
+		return s.netrc("gitlab")
+	case "kubernetes":
+		return s.kubeconfig()
+	default:
+		return nil
+	}
+}
+
+// aws returns a credential per profile of the shared credentials file.
+func (s LocalSources) aws() []ImportedCredential {
+	b, err := ioutil.ReadFile(s.AWSCredentials)
+	if err != nil {
+		return nil
+	}
+
+	var creds []ImportedCredential
+	for _, section := range parseINI(b) {
+		id, secret := section.values["aws_access_key_id"], section.values["aws_secret_access_key"]
+		if id == "" || secret == "" {
+			continue
+		}
+		fields := map[string]string{"accesskeyid": id, "secretaccesskey": secret}
+		if token := section.values["aws_session_token"]; token != "" {
+			fields["sessiontoken"] = token
+		}
+		creds = append(creds, ImportedCredential{
+			Source:  s.AWSCredentials,
+			Profile: "profile " + section.name,
+			Fields:  fields,
+		})
+	}
+	return creds
+}
+
+// gh returns a credential per host logged in with the GitHub CLI. Hosts whose
+// token is kept in the system keyring are left out.
+func (s LocalSources) gh() []ImportedCredential {
+	b, err := ioutil.ReadFile(s.GHHosts)
+	if err != nil {
+		return nil
+	}
+	hosts := map[string]struct {
+		User       string `yaml:"user"`
+		OAuthToken string `yaml:"oauth_token"`
+	}{}
+	if err := yaml.Unmarshal(b, &hosts); err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(hosts))
+	for name := range hosts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var creds []ImportedCredential
+	for _, name := range names {
+		host := hosts[name]
+		if host.User == "" || host.OAuthToken == "" {
+			continue
+		}
+		creds = append(creds, ImportedCredential{
+			Source:  s.GHHosts,
+			Profile: "host " + name,
+			Fields:  map[string]string{"username": host.User, "token": host.OAuthToken},
+		})
+	}
+	return creds
+}
+
+// kubeconfig returns a credential per context of the kubeconfig, each with
+// the kubeconfig reduced to that context, its cluster and its user, so the
+// secrets of the other ones are not uploaded.
+func (s LocalSources) kubeconfig() []ImportedCredential {
+	b, err := ioutil.ReadFile(s.Kubeconfig)
+	if err != nil {
+		return nil
+	}
+	var config yaml.MapSlice
+	if err := yaml.Unmarshal(b, &config); err != nil {
+		return nil
+	}
+
+	var creds []ImportedCredential
+	for _, item := range yamlList(config, "contexts") {
+		ctx, _ := item.(yaml.MapSlice)
+		name, _ := yamlValue(ctx, "name").(string)
+		reduced, err := yaml.Marshal(reduceKubeconfig(config, ctx))
+		if name == "" || err != nil {
+			continue
+		}
+		creds = append(creds, ImportedCredential{
+			Source:  s.Kubeconfig,
+			Profile: "context " + name,
+			Fields:  map[string]string{"kubeconfig": string(reduced), "context": name},
+		})
+	}
+	return creds
+}
+
+// reduceKubeconfig returns the kubeconfig with ctx as its only context and
+// current context, and only the cluster and the user ctx refers to.
+func reduceKubeconfig(config, ctx yaml.MapSlice) yaml.MapSlice {
+	refs, _ := yamlValue(ctx, "context").(yaml.MapSlice)
+	keep := map[string]interface{}{
+		"clusters": yamlValue(refs, "cluster"),
+		"users":    yamlValue(refs, "user"),
+		"contexts": yamlValue(ctx, "name"),
+	}
+
+	reduced := make(yaml.MapSlice, 0, len(config))
+	for _, item := range config {
+		key, _ := item.Key.(string)
+		name, ok := keep[key]
+		switch {
+		case key == "current-context":
+			item.Value = yamlValue(ctx, "name")
+		case ok:
+			var entries []interface{}
+			for _, entry := range yamlList(config, key) {
+				e, _ := entry.(yaml.MapSlice)
+				if yamlValue(e, "name") == name {
+					entries = append(entries, entry)
+				}
+			}
+			item.Value = entries
+		}
+		reduced = append(reduced, item)
+	}
+	return reduced
+}
+
+// yamlValue returns the value of the key of a YAML mapping, nil when missing.
+func yamlValue(m yaml.MapSlice, key string) interface{} {
+	for _, item := range m {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return nil
+}
+
+// yamlList returns the list of the key of a YAML mapping, nil when missing.
+func yamlList(m yaml.MapSlice, key string) []interface{} {
+	list, _ := yamlValue(m, key).([]interface{})
+	return list
+}
+
+// netrc returns a credential per machine of the .netrc whose name contains
+// the provider, e.g. github.com for github.
+func (s LocalSources) netrc(provider string) []ImportedCredential {
+	b, err := ioutil.ReadFile(s.Netrc)
+	if err != nil {
+		return nil
+	}
+
+	var creds []ImportedCredential
+	for _, m := range parseNetrc(b) {
+		if !strings.Contains(m.name, provider) || m.login == "" || m.password == "" {
+			continue
+		}
+		creds = append(creds, ImportedCredential{
+			Source:  s.Netrc,
+			Profile: "machine " + m.name,
+			Fields:  map[string]string{"username": m.login, "token": m.password},
+		})
+	}
+	return creds
+}
+
+type iniSection struct {
+	name   string
+	values map[string]string
+}
+
+// parseINI returns the sections of an INI file in order, skipping comments
+// and the keys out of a section.
+func parseINI(b []byte) []iniSection {
+	var sections []iniSection
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";"):
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			sections = append(sections, iniSection{name: name, values: map[string]string{}})
+		case len(sections) > 0:
+			pair := strings.SplitN(line, "=", 2)
+			if len(pair) == 2 {
+				sections[len(sections)-1].values[strings.TrimSpace(pair[0])] = strings.TrimSpace(pair[1])
+			}
+		}
+	}
+	return sections
+}
+
+type netrcMachine struct {
+	name     string
+	login    string
+	password string
+}
+
+// parseNetrc returns the machines of a .netrc file. The default entry and
+// macros are skipped.
+func parseNetrc(b []byte) []netrcMachine {
+	var machines []netrcMachine
+	var current *netrcMachine
+	fields := strings.Fields(string(b))
+	for i := 0; i < len(fields); i++ {
+		key := fields[i]
+		if key == "default" {
+			current = nil
+			continue
+		}
+		if i+1 >= len(fields) {
+			break
+		}
+		value := fields[i+1]
+		i++
+
+		switch {
+		case key == "machine":
+			machines = append(machines, netrcMachine{name: value})
+			current = &machines[len(machines)-1]
+		case key == "macdef":
+			// a macro runs until an empty line, which Fields cannot see
+			return machines
+		case current == nil:
+		case key == "login":
+			current.login = value
+		case key == "password":
+			current.password = value
+		}
+	}
+	return machines
+}
@@ -3,7 +3,10 @@ module rocket/formula
 go 1.14
 
 require (
+	dennis v0.0.0-00010101000000-000000000000
+	github.com/AlecAivazis/survey/v2 v2.0.7
 	github.com/ZupIT/ritchie-cli v0.0.0-20200806162951-cd8acdae49af
 	github.com/google/uuid v1.1.1
-	github.com/gookit/color v1.2.5
 )
+
+replace dennis => ./dennis
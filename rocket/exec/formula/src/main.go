@@ -1,23 +1,43 @@
 package main
 
 import (
-	"net"
+	"dennis"
+	"flag"
 	"os"
 	"rocket/formula/pkg/formula"
-	"strings"
+
+	"github.com/ZupIT/ritchie-cli/pkg/prompt"
 )
 
 func main() {
+	values, err := formula.EnvValues()
+	if err != nil {
+		prompt.Error(err.Error())
+		os.Exit(dennis.ExitFailure)
+	}
+	context := flag.String("context", dennis.EnvInput("DENNIS_CONTEXT", "pick"), "context where the remote formula runs")
+	command := flag.String("command", dennis.EnvInput("DENNIS_COMMAND", "pick"), "command of the remote formula, e.g. \"rit aws list bucket\"")
+	file := flag.String("file", dennis.EnvInput("DENNIS_INPUT_FILE", "none"), "JSON or YAML file with the context, command and inputs")
+	flag.Var(values, "input", "remote input as name=value, can be repeated")
+	manifest := flag.String("manifest", dennis.EnvInput("DENNIS_MANIFEST", "none"), "JSON or YAML manifest of invocations to run in batch")
+	timeout := flag.Duration("timeout", dennis.EnvDuration("DENNIS_TIMEOUT"), "how long an execution is awaited, e.g. 5m")
+	parallel := flag.Int("parallel", dennis.EnvInt("DENNIS_PARALLEL"), "how many invocations of the manifest run at the same time")
+	output := flag.String("output", dennis.EnvString("DENNIS_OUTPUT", "text"), "format of the result: text, json, yaml or table")
+	rerun := flag.String("rerun", dennis.EnvInput("DENNIS_RERUN", "none"), "ID of a previous execution to run again with the same inputs")
+	flag.Parse()
+
 	formula.Inputs{
-		Username: os.Getenv("USERNAME"),
-		Password: os.Getenv("PASSWORD"),
-		IPAddr:   localAddr(),
+		Username:  os.Getenv("USERNAME"),
+		Password:  os.Getenv("PASSWORD"),
+		Metadata:  dennis.LocalMetadata(),
+		Context:   *context,
+		Command:   *command,
+		InputFile: *file,
+		Values:    values,
+		Manifest:  *manifest,
+		Parallel:  *parallel,
+		Timeout:   *timeout,
+		Output:    *output,
+		Rerun:     *rerun,
 	}.Run()
 }
-
-func localAddr() string {
-	conn, _ := net.Dial("udp", "8.8.8.8:80")
-	defer conn.Close()
-	localAddr := conn.LocalAddr().(*net.UDPAddr)
-	return strings.Split(localAddr.String(), ":")[0]
-}
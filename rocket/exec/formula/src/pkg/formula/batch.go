@@ -0,0 +1,204 @@
+package formula
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"dennis"
+
+	"github.com/ZupIT/ritchie-cli/pkg/prompt"
+)
+
+const defaultBatchTimeout = 5 * time.Minute
+
+// errNotSubmitted is the error of the invocations left once the batch is
+// interrupted.
+var errNotSubmitted = errors.New("not submitted")
+
+type batchResult struct {
+	inv       dennis.Invocation
+	id        string
+	execution dennis.ExecutionResponse
+	err       error
+}
+
+func (r batchResult) failed() bool {
+	return r.err != nil || r.execution.Content.StatusCode != 0
+}
+
+// output returns the machine readable form of the result, with the error of
+// the invocations that failed before their execution was ready.
+func (r batchResult) output() dennis.ExecutionOutput {
+	if r.err == nil {
+		out := dennis.NewExecutionOutput(r.inv.Context, r.execution)
+		if out.Command == "" {
+			out.Command = r.inv.Command
+		}
+		return out
+	}
+
+	status := "Failed"
+	if errors.Is(r.err, errNotSubmitted) {
+		status = "Not submitted"
+	}
+	return dennis.ExecutionOutput{
+		ID:      r.id,
+		Context: r.inv.Context,
+		Command: r.inv.Command,
+		Status:  status,
+		Error:   r.err.Error(),
+	}
+}
+
+// writeResults prints the summary of the results, or writes them to w in the
+// output format when it is not text.
+func (in Inputs) writeResults(w io.Writer, results []batchResult, summary func([]batchResult)) error {
+	if in.Output == dennis.OutputText {
+		summary(results)
+		return nil
+	}
+
+	outs := make([]dennis.ExecutionOutput, len(results))
+	for i, r := range results {
+		outs[i] = r.output()
+	}
+	return dennis.WriteExecutions(w, in.Output, outs)
+}
+
+// runBatch submits every invocation of the manifest, running at most Parallel
+// of them at the same time, and prints a summary once all of them finish.
+// Once goCtx is canceled the invocations left are not submitted.
+func (in Inputs) runBatch(goCtx context.Context, client *dennis.Client, formulasResp dennis.FormulasResponse, w io.Writer) error {
+	manifest, err := dennis.LoadManifest(in.Manifest)
+	if err != nil {
+		return err
+	}
+	for i, inv := range manifest.Invocations {
+		if form, ok := formulasResp.Formulas.Find(inv.Command); ok {
+			manifest.Invocations[i].Inputs = withCredentials(form, inv.Inputs)
+		}
+	}
+	if err = manifest.Validate(formulasResp); err != nil {
+		return err
+	}
+
+	timeout, err := manifest.TimeoutDuration(defaultBatchTimeout)
+	if err != nil {
+		return err
+	}
+	opts := in.waitOptions(timeout)
+
+	parallel := manifest.Parallel
+	if in.Parallel > 0 {
+		parallel = in.Parallel
+	}
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	total := len(manifest.Invocations)
+	results := make([]batchResult, total)
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i, inv := range manifest.Invocations {
+		if goCtx.Err() != nil {
+			results[i] = batchResult{inv: inv, err: errNotSubmitted}
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-goCtx.Done():
+			results[i] = batchResult{inv: inv, err: errNotSubmitted}
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, inv dennis.Invocation) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			form, _ := formulasResp.Formulas.Find(inv.Command)
+			results[i] = in.runInvocation(goCtx, client, inv, form, opts, fmt.Sprintf("[%d/%d]", i+1, total))
+		}(i, inv)
+	}
+	wg.Wait()
+
+	if err := in.writeResults(w, results, printBatchSummary); err != nil {
+		return err
+	}
+
+	if err := goCtx.Err(); err != nil {
+		return fmt.Errorf("batch interrupted: %w", err)
+	}
+	for _, r := range results {
+		if r.failed() {
+			return errors.New("some executions failed")
+		}
+	}
+	return nil
+}
+
+func (in Inputs) runInvocation(goCtx context.Context, client *dennis.Client, inv dennis.Invocation, form dennis.Formula, opts dennis.WaitOptions, tag string) batchResult {
+	r := batchResult{inv: inv}
+	if goCtx.Err() != nil {
+		r.err = errNotSubmitted
+		return r
+	}
+
+	r.id, r.err = in.submit(goCtx, client, inv, form)
+	if r.err != nil {
+		prompt.Error(fmt.Sprintf("%s %s on %s failed: %s", tag, inv.Command, inv.Context, r.err))
+		return r
+	}
+	prompt.Info(fmt.Sprintf("%s %s on %s submitted: %s", tag, inv.Command, inv.Context, r.id))
+
+	r.execution, r.err = client.WaitExecution(goCtx, inv.Context, r.id, opts)
+	if r.err != nil {
+		prompt.Error(fmt.Sprintf("%s %s failed: %s", tag, r.id, r.err))
+		return r
+	}
+	prompt.Success(fmt.Sprintf("%s %s finished with status code %d", tag, r.id, r.execution.Content.StatusCode))
+	return r
+}
+
+func printBatchSummary(results []batchResult) {
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tCONTEXT\tCOMMAND\tEXECUTION ID\tSTATUS CODE\tDURATION\tERROR")
+	failed, skipped := 0, 0
+	for i, r := range results {
+		code, duration, errMsg := "-", "-", ""
+		if r.err != nil {
+			errMsg = r.err.Error()
+		} else {
+			code = strconv.Itoa(r.execution.Content.StatusCode)
+			duration = r.execution.Content.Duration().String()
+		}
+		switch {
+		case errors.Is(r.err, errNotSubmitted):
+			skipped++
+		case r.failed():
+			failed++
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", i+1, r.inv.Context, r.inv.Command, r.id, code, duration, errMsg)
+	}
+	w.Flush()
+	fmt.Println()
+
+	summary := fmt.Sprintf("%d executions, %d succeeded, %d failed", len(results), len(results)-failed-skipped, failed)
+	if skipped > 0 {
+		summary += fmt.Sprintf(", %d not submitted", skipped)
+	}
+	if failed > 0 || skipped > 0 {
+		prompt.Error(summary)
+	} else {
+		prompt.Success(summary)
+	}
+}
@@ -0,0 +1,97 @@
+package formula
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"dennis"
+)
+
+var coffeeFormulas = dennis.FormulasResponse{
+	Contexts: dennis.Contexts{{Name: "DEV"}, {Name: "QA"}},
+	Formulas: dennis.Formulas{
+		{
+			Command: "rit coffee",
+			Inputs: dennis.Inputs{
+				{Name: "name", Type: "text"},
+				{Name: "coffee_type", Type: "text", Default: "espresso", Items: dennis.Items{"espresso", "latte"}},
+				{Name: "Size", Type: "text", Default: "small"},
+			},
+		},
+		{
+			Command: "rit github list repos",
+			Inputs: dennis.Inputs{
+				{Name: "token", Type: "CREDENTIAL_GITHUB_TOKEN"},
+				{Name: "org", Type: "text"},
+			},
+		},
+	},
+}
+
+// tempHome points the home dir to a temporary one, so the history and the
+// caches of the tests are not kept.
+func tempHome(t *testing.T) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "formula")
+	if err != nil {
+		t.Fatal(err)
+	}
+	home := os.Getenv("HOME")
+	os.Setenv("HOME", dir)
+	t.Cleanup(func() {
+		os.Setenv("HOME", home)
+		os.RemoveAll(dir)
+	})
+	return dir
+}
+
+func newClient(t *testing.T, handler http.HandlerFunc) *dennis.Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	client := dennis.NewClient(srv.URL, "user", "pass")
+	client.Tokens = dennis.TokenStore{}
+	return client
+}
+
+func TestInputs_runBatch_interrupted(t *testing.T) {
+	home := tempHome(t)
+	manifest := filepath.Join(home, "manifest.yml")
+	content := "parallel: 1\ninvocations:\n" +
+		"  - {context: DEV, command: rit coffee, inputs: {name: a}}\n" +
+		"  - {context: DEV, command: rit coffee, inputs: {name: b}}\n" +
+		"  - {context: QA, command: rit coffee, inputs: {name: c}}\n"
+	if err := ioutil.WriteFile(manifest, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	goCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var mu sync.Mutex
+	commands := 0
+	client := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/commands" {
+			mu.Lock()
+			commands++
+			mu.Unlock()
+			// Ctrl-C while the first command is sent
+			cancel()
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	err := Inputs{Manifest: manifest, Output: dennis.OutputText}.runBatch(goCtx, client, coffeeFormulas, ioutil.Discard)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("runBatch() error = %v, want %v", err, context.Canceled)
+	}
+	if commands != 1 {
+		t.Errorf("runBatch() sent %d commands after the interruption, want 1", commands)
+	}
+}
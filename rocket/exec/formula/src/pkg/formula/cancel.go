@@ -0,0 +1,36 @@
+package formula
+
+import (
+	"fmt"
+
+	"dennis"
+
+	"github.com/ZupIT/ritchie-cli/pkg/prompt"
+)
+
+// offerCancel asks, once waiting was interrupted, whether the remote execution
+// must be canceled too, and reports whether it was.
+func offerCancel(client *dennis.Client, ctx, cmdID string) bool {
+	fmt.Println()
+	cancel, err := prompt.NewSurveyBool().Bool("Cancel the remote execution too?", []string{"no", "yes"})
+	if err != nil || !cancel {
+		return false
+	}
+	return cancelExecution(client, ctx, cmdID)
+}
+
+// cancelExecution cancels the remote execution, reporting whether it was.
+func cancelExecution(client *dennis.Client, ctx, cmdID string) bool {
+	prompt.Info("Canceling execution...")
+	err := client.CancelExecution(ctx, cmdID)
+	switch {
+	case dennis.IsConflict(err):
+		prompt.Info("The execution has already finished")
+		return false
+	case err != nil:
+		prompt.Error(err.Error())
+		return false
+	}
+	prompt.Success("done")
+	return true
+}
@@ -0,0 +1,32 @@
+package formula
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCancelExecution(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		want   bool
+	}{
+		{name: "canceled", status: http.StatusAccepted, want: true},
+		{name: "already finished", status: http.StatusConflict},
+		{name: "failed", status: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/executions/test-07/cancel" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+				w.WriteHeader(tt.status)
+			})
+
+			if got := cancelExecution(client, "DEV", "test-07"); got != tt.want {
+				t.Errorf("cancelExecution() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
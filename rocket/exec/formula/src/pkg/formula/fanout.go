@@ -0,0 +1,188 @@
+package formula
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"dennis"
+
+	"github.com/AlecAivazis/survey/v2"
+
+	"github.com/ZupIT/ritchie-cli/pkg/prompt"
+)
+
+const (
+	allContexts        = "all"
+	allContextsOpt     = "All contexts"
+	severalContextsOpt = "Several contexts"
+)
+
+// contexts returns the contexts given up front, a comma separated list or
+// "all" for every context of the server.
+func (in Inputs) contexts(resp dennis.FormulasResponse) []string {
+	if strings.TrimSpace(in.Context) == allContexts {
+		return resp.Contexts.Names()
+	}
+
+	var contexts []string
+	for _, ctx := range strings.Split(in.Context, ",") {
+		if ctx = strings.TrimSpace(ctx); ctx != "" && !contains(contexts, ctx) {
+			contexts = append(contexts, ctx)
+		}
+	}
+	return contexts
+}
+
+// selectContexts prompts for one context, keeping the single choice the
+// default, and offers all of them or a multiple choice when there are more.
+func selectContexts(names []string) ([]string, error) {
+	if len(names) < 2 {
+		ctx, err := prompt.NewSurveyList().List("Select a context", names)
+		return []string{ctx}, err
+	}
+
+	ctx, err := prompt.NewSurveyList().List("Select a context", append(names, allContextsOpt, severalContextsOpt))
+	if err != nil {
+		return nil, err
+	}
+
+	switch ctx {
+	case allContextsOpt:
+		return names, nil
+	case severalContextsOpt:
+		var contexts []string
+		err = survey.AskOne(&survey.MultiSelect{
+			Message: "Select the contexts",
+			Options: names,
+		}, &contexts, survey.WithValidator(survey.Required))
+		return contexts, err
+	default:
+		return []string{ctx}, nil
+	}
+}
+
+// validate checks the invocation on every context, reporting each problem once.
+func validate(resp dennis.FormulasResponse, inv dennis.Invocation, contexts []string) error {
+	if len(contexts) == 0 {
+		inv.Context = ""
+		return inv.Validate(resp)
+	}
+
+	var errs dennis.ValidationError
+	for _, ctx := range contexts {
+		inv.Context = ctx
+		err := inv.Validate(resp)
+		if err == nil {
+			continue
+		}
+		for _, e := range err.(dennis.ValidationError) {
+			if !contains(errs, e) {
+				errs = append(errs, e)
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// fanOut dispatches the same invocation to every context at the same time and
+// compares the results.
+func (in Inputs) fanOut(goCtx context.Context, client *dennis.Client, inv dennis.Invocation, form dennis.Formula, contexts []string, w io.Writer) error {
+	opts := in.waitOptions(defaultBatchTimeout)
+	results := make([]batchResult, len(contexts))
+	var wg sync.WaitGroup
+	for i, ctx := range contexts {
+		wg.Add(1)
+		go func(i int, inv dennis.Invocation) {
+			defer wg.Done()
+			results[i] = in.runInvocation(goCtx, client, inv, form, opts, "["+inv.Context+"]")
+		}(i, dennis.Invocation{Context: ctx, Command: inv.Command, Inputs: inv.Inputs})
+	}
+	wg.Wait()
+
+	if err := in.writeResults(w, results, printComparison); err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		if r.failed() {
+			return errors.New("the execution failed on some contexts")
+		}
+	}
+	return nil
+}
+
+func printComparison(results []batchResult) {
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CONTEXT\tEXECUTION ID\tSTATUS CODE\tDURATION\tERROR")
+	for _, r := range results {
+		code, duration, errMsg := "-", "-", ""
+		if r.err != nil {
+			errMsg = r.err.Error()
+		} else {
+			code = strconv.Itoa(r.execution.Content.StatusCode)
+			duration = r.execution.Content.Duration().String()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.inv.Context, r.id, code, duration, errMsg)
+	}
+	w.Flush()
+	fmt.Println()
+
+	codes := groupBy(results, func(r batchResult) string {
+		return strconv.Itoa(r.execution.Content.StatusCode)
+	})
+	if len(codes) > 1 {
+		prompt.Warning("status code differs between contexts:")
+		printGroups(codes, "status code ")
+	}
+
+	outputs := groupBy(results, func(r batchResult) string {
+		return r.execution.Content.FormulaOut
+	})
+	if len(outputs) > 1 {
+		prompt.Warning("stdout differs between contexts:")
+		printGroups(outputs, "")
+	} else if len(outputs) == 1 {
+		prompt.Success("stdout is the same on every context:")
+		printGroups(outputs, "")
+	}
+}
+
+// groupBy groups the contexts of the finished executions by key.
+func groupBy(results []batchResult, key func(batchResult) string) map[string][]string {
+	groups := map[string][]string{}
+	for _, r := range results {
+		if r.err != nil {
+			continue
+		}
+		k := key(r)
+		groups[k] = append(groups[k], r.inv.Context)
+	}
+	return groups
+}
+
+func printGroups(groups map[string][]string, prefix string) {
+	keys := make([]string, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		fmt.Printf("%s:\n", strings.Join(groups[k], ", "))
+		prompt.Info(prefix + k)
+	}
+	fmt.Println()
+}
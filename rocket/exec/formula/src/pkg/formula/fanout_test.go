@@ -0,0 +1,77 @@
+package formula
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"dennis"
+)
+
+func TestInputs_contexts(t *testing.T) {
+	tests := []struct {
+		name    string
+		context string
+		want    []string
+	}{
+		{name: "none"},
+		{name: "one", context: "DEV", want: []string{"DEV"}},
+		{name: "list", context: " DEV, QA,,DEV ", want: []string{"DEV", "QA"}},
+		{name: "all", context: "all", want: []string{"DEV", "QA"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (Inputs{Context: tt.context}).contexts(coffeeFormulas); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("contexts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate_contexts(t *testing.T) {
+	inv := dennis.Invocation{Command: "rit coffee", Inputs: map[string]string{"milk": "oat"}}
+	want := dennis.ValidationError{`unknown input "milk"`, `missing input "name"`, `context "PROD" not found`}
+
+	err := validate(coffeeFormulas, inv, []string{"DEV", "QA", "PROD"})
+	if got, ok := err.(dennis.ValidationError); !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("validate() error = %#v, want %#v", err, want)
+	}
+}
+
+func TestInputs_fanOut_output(t *testing.T) {
+	tempHome(t)
+	client := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch ctx := r.Header.Get("x-ctx"); {
+		case r.URL.Path == "/commands" && ctx == "QA":
+			w.WriteHeader(http.StatusInternalServerError)
+		case r.URL.Path == "/commands":
+			w.WriteHeader(http.StatusCreated)
+		case strings.HasSuffix(r.URL.Path, "/logs"):
+			w.WriteHeader(http.StatusNotImplemented)
+		default:
+			_, _ = w.Write([]byte(`{"status": "Ready", "content": {"statusCode": 0, "formulaOutput": "coffee"}}`))
+		}
+	})
+
+	var stdout strings.Builder
+	inv := dennis.Invocation{Command: "rit coffee", Inputs: map[string]string{"name": "Dennis"}}
+	err := Inputs{Output: dennis.OutputJSON}.fanOut(context.Background(), client, inv, coffeeFormulas.Formulas[0], []string{"DEV", "QA"}, &stdout)
+	if err == nil {
+		t.Error("fanOut() error = nil, want the failure of QA")
+	}
+
+	var outs []dennis.ExecutionOutput
+	if err := json.Unmarshal([]byte(stdout.String()), &outs); err != nil {
+		t.Fatalf("fanOut() wrote %q: %v", stdout.String(), err)
+	}
+	got := make([]string, len(outs))
+	for i, out := range outs {
+		got[i] = out.Context + ":" + out.Status + ":" + out.Stdout
+	}
+	if want := []string{"DEV:Ready:coffee", "QA:Failed:"}; !reflect.DeepEqual(got, want) || outs[1].Error == "" {
+		t.Errorf("fanOut() wrote %v, want %v with the error of QA", got, want)
+	}
+}
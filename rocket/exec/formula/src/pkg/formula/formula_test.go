@@ -0,0 +1,123 @@
+package formula
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"dennis"
+)
+
+func TestInputs_withInputFile(t *testing.T) {
+	file := filepath.Join(tempHome(t), "invocation.yml")
+	content := "context: DEV\ncommand: rit coffee\ninputs:\n  name: Dennis\n  coffee_type: latte\n"
+	if err := ioutil.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		in      Inputs
+		want    dennis.Invocation
+		wantErr bool
+	}{
+		{
+			name: "file",
+			in:   Inputs{InputFile: file},
+			want: dennis.Invocation{Context: "DEV", Command: "rit coffee", Inputs: map[string]string{"name": "Dennis", "coffee_type": "latte"}},
+		},
+		{
+			name: "flags and env vars win",
+			in:   Inputs{InputFile: file, Context: "QA", Values: Values{"coffee_type": "espresso"}},
+			want: dennis.Invocation{Context: "QA", Command: "rit coffee", Inputs: map[string]string{"name": "Dennis", "coffee_type": "espresso"}},
+		},
+		{
+			name: "no file",
+			in:   Inputs{Command: "rit coffee", Values: Values{"name": "Dennis"}},
+			want: dennis.Invocation{Command: "rit coffee", Inputs: map[string]string{"name": "Dennis"}},
+		},
+		{name: "missing file", in: Inputs{InputFile: file + ".json"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in, err := tt.in.withInputFile()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("withInputFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := in.invocation(); !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("invocation() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInputs_readInputs(t *testing.T) {
+	home := tempHome(t)
+	dir := filepath.Join(home, ".rit", "credentials", "default")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	cred := `{"service": "github", "credential": {"token": "ghp_token"}}`
+	if err := ioutil.WriteFile(filepath.Join(dir, "github"), []byte(cred), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		given map[string]string
+		want  map[string]string
+	}{
+		{name: "credential of rit", given: map[string]string{"org": "ZupIT"}, want: map[string]string{"token": "ghp_token", "org": "ZupIT"}},
+		{name: "given credential wins", given: map[string]string{"token": "ghp_other"}, want: map[string]string{"token": "ghp_other"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Inputs{}.readInputs(coffeeFormulas.Formulas[1], tt.given, false)
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readInputs() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		inv      dennis.Invocation
+		contexts []string
+		want     dennis.ValidationError
+	}{
+		{
+			name:     "valid",
+			inv:      dennis.Invocation{Command: "rit coffee", Inputs: map[string]string{"name": "Dennis"}},
+			contexts: []string{"DEV"},
+		},
+		{
+			name:     "missing and unknown inputs",
+			inv:      dennis.Invocation{Command: "rit coffee", Inputs: map[string]string{"milk": "oat"}},
+			contexts: []string{"DEV"},
+			want:     dennis.ValidationError{`unknown input "milk"`, `missing input "name"`},
+		},
+		{
+			name: "missing context",
+			inv:  dennis.Invocation{Command: "rit coffee", Inputs: map[string]string{"name": "Dennis"}},
+			want: dennis.ValidationError{"missing context"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate(coffeeFormulas, tt.inv, tt.contexts)
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("validate() error = %v", err)
+				}
+				return
+			}
+			if got, ok := err.(dennis.ValidationError); !ok || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validate() error = %#v, want %#v", err, tt.want)
+			}
+		})
+	}
+}
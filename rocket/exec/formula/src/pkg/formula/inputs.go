@@ -0,0 +1,158 @@
+package formula
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"dennis"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/ZupIT/ritchie-cli/pkg/prompt"
+)
+
+// readInputs returns the values of the formula inputs: the ones given up
+// front, the credentials set with rit and, on a terminal, the prompted ones.
+// Without a TTY the missing ones are left out to take their default. Inputs
+// whose condition does not hold for the earlier values are not prompted.
+func (in Inputs) readInputs(form dennis.Formula, given map[string]string, interactive bool) (map[string]string, error) {
+	values := withCredentials(form, given)
+	if !interactive {
+		return values, nil
+	}
+
+	cache := dennis.DefaultInputCache()
+	for _, input := range form.Inputs {
+		if _, ok := values[input.Name]; ok || !input.Condition.Met(values) {
+			continue
+		}
+
+		value, err := readInput(form.Command, input, cache)
+		if err != nil {
+			return nil, fmt.Errorf("error reading inputs: %w", err)
+		}
+		values[input.Name] = value
+	}
+	return values, nil
+}
+
+// withCredentials returns the given values along with the credential inputs
+// not given that are found in the credentials set with rit.
+func withCredentials(form dennis.Formula, given map[string]string) map[string]string {
+	creds := dennis.DefaultLocalCredentials()
+	values := map[string]string{}
+	for name, value := range given {
+		values[name] = value
+	}
+
+	for _, input := range form.Inputs {
+		if _, ok := values[input.Name]; ok || !dennis.IsCredentialType(input.Type) {
+			continue
+		}
+		if value, ok := creds.Resolve(input.Type); ok {
+			values[input.Name] = value
+		}
+	}
+	return values
+}
+
+// readInput prompts for the value of an input according to its type. Values
+// of cached inputs are kept to be offered the next time.
+func readInput(command string, input dennis.Input, cache dennis.InputCache) (string, error) {
+	label := input.Label
+	if label == "" {
+		label = input.Name + ": "
+	}
+
+	switch {
+	case input.Type == "text":
+		value, err := readText(command, label, input, cache)
+		if err == nil && input.Cached() {
+			_ = cache.Put(command, input.Name, value, input.Cache.Qty)
+		}
+		return value, err
+	case input.Type == "bool":
+		items := input.Items
+		if len(items) == 0 {
+			items = dennis.Items{"false", "true"}
+			if input.Default == "true" {
+				items = dennis.Items{"true", "false"}
+			}
+		}
+		value, err := prompt.NewSurveyBool().Bool(label, items)
+		return strconv.FormatBool(value), err
+	case input.Type == "number" || input.Type == "integer" || input.Type == "date" || input.Type == "path":
+		return askText(label, input)
+	case input.Type == "password":
+		return askPassword(label, input)
+	case dennis.IsCredentialType(input.Type):
+		if input.Label == "" {
+			provider, field, _ := dennis.ParseCredentialType(input.Type)
+			label = fmt.Sprintf("Type your %s %s: ", provider, field)
+		}
+		return askPassword(label, input)
+	default:
+		return "", fmt.Errorf("input %q has the unsupported type %q", input.Name, input.Type)
+	}
+}
+
+// readText offers the items of the input, with the recent values first when
+// it is cached, or prompts for a text taking the default when left empty.
+func readText(command, label string, input dennis.Input, cache dennis.InputCache) (string, error) {
+	items := []string(input.Items)
+	newLabel := ""
+	if input.Cached() {
+		items = nil
+		for _, item := range append(cache.Get(command, input.Name), input.Items...) {
+			if !contains(items, item) {
+				items = append(items, item)
+			}
+		}
+
+		newLabel = input.Cache.NewLabel
+		if newLabel == "" {
+			newLabel = dennis.DefaultCacheNewLabel
+		}
+	}
+
+	if len(items) > 0 {
+		if newLabel != "" {
+			items = append(items, newLabel)
+		}
+		choice, err := prompt.NewSurveyList().List(label, items)
+		if err != nil || choice != newLabel {
+			return choice, err
+		}
+	}
+
+	return askText(label, input)
+}
+
+// askText prompts for a text, taking the default when left empty, until it
+// passes the rules of the input.
+func askText(label string, input dennis.Input) (string, error) {
+	value := ""
+	q := &survey.Input{Message: label, Default: input.Default}
+	err := survey.AskOne(q, &value, survey.WithValidator(func(ans interface{}) error {
+		if s, _ := ans.(string); s == "" && input.Default == "" {
+			return errors.New("Value is required")
+		}
+		return check(input, ans)
+	}))
+	return value, err
+}
+
+// askPassword prompts for a hidden text until it passes the rules of the input.
+func askPassword(label string, input dennis.Input) (string, error) {
+	value := ""
+	q := &survey.Password{Message: label}
+	err := survey.AskOne(q, &value, survey.WithValidator(func(ans interface{}) error {
+		return check(input, ans)
+	}))
+	return value, err
+}
+
+func check(input dennis.Input, ans interface{}) error {
+	s, _ := ans.(string)
+	return input.Check(s)
+}
@@ -0,0 +1,127 @@
+package formula
+
+import (
+	"fmt"
+	"strconv"
+
+	"dennis"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/ZupIT/ritchie-cli/pkg/prompt"
+)
+
+// rerun fills the context, command and values of the execution to run again.
+// Values given up front win over the ones of the previous execution.
+func (in Inputs) rerun(client *dennis.Client, resp dennis.FormulasResponse, interactive bool) (Inputs, error) {
+	entry, _, err := dennis.DefaultHistory().Find(in.Rerun)
+	if err != nil {
+		prompt.Warning(err.Error())
+	}
+
+	if in.Context == "" {
+		in.Context = entry.Context
+	}
+	if in.Context == "" {
+		if !interactive {
+			return in, fmt.Errorf("the context of execution %s is unknown, set it with -context", in.Rerun)
+		}
+		if in.Context, err = prompt.NewSurveyList().List("Select the context of the execution", resp.Contexts.Names()); err != nil {
+			return in, err
+		}
+	}
+
+	prompt.Info("Obtaining execution...")
+	execResp, err := client.GetExecution(in.Context, in.Rerun)
+	if err != nil {
+		return in, err
+	}
+	prompt.Success("done")
+
+	cont := execResp.Content
+	if in.Command == "" {
+		in.Command = cont.Command
+	}
+	if in.Command == "" {
+		in.Command = entry.Command
+	}
+	if in.Command == "" {
+		return in, fmt.Errorf("the command of execution %s is unknown, set it with -command", in.Rerun)
+	}
+
+	form, ok := resp.Formulas.Find(in.Command)
+	if !ok {
+		return in, fmt.Errorf("formula %q not found", in.Command)
+	}
+
+	// inputs the formula no longer has, e.g. the IPAddr sent by older
+	// versions, are left out
+	values := Values{}
+	for _, input := range cont.FormulaInputs {
+		if _, ok := form.Inputs.Find(input.Name); ok {
+			values[input.Name] = input.Value
+		}
+	}
+	for name, value := range in.Values {
+		values[name] = value
+	}
+	in.Values = values
+	return in, nil
+}
+
+// editInputs offers to edit the values of a rerun, prompting each input with
+// its previous value as default.
+func editInputs(form dennis.Formula, values map[string]string) (map[string]string, error) {
+	edit, err := prompt.NewSurveyBool().Bool("Edit the inputs of the execution?", []string{"no", "yes"})
+	if err != nil || !edit {
+		return values, err
+	}
+
+	edited := map[string]string{}
+	for name, value := range values {
+		edited[name] = value
+	}
+
+	for _, input := range form.Inputs {
+		value, ok := edited[input.Name]
+		if !ok {
+			continue
+		}
+
+		answer := ""
+		if err := survey.AskOne(editPrompt(input, value), &answer); err != nil {
+			return nil, fmt.Errorf("error reading inputs: %w", err)
+		}
+		if answer != "" {
+			edited[input.Name] = answer
+		}
+	}
+	return edited, nil
+}
+
+// editPrompt returns the prompt of an input of a rerun, with the previous
+// value as default. The items of a cached input are only suggestions, so a
+// previous value that is not one of them is offered as the default of a text
+// prompt. Secrets are never shown.
+func editPrompt(input dennis.Input, value string) survey.Prompt {
+	label := input.Label
+	if label == "" {
+		label = input.Name
+	}
+
+	switch {
+	case input.Type == "password" || dennis.IsCredentialType(input.Type):
+		return &survey.Password{Message: label + " (empty keeps the previous value)"}
+	case input.Type == "bool":
+		q := &survey.Select{Message: label, Options: []string{"false", "true"}}
+		if b, err := strconv.ParseBool(value); err == nil {
+			q.Default = strconv.FormatBool(b)
+		}
+		return q
+	case len(input.Items) > 0 && contains(input.Items, value):
+		return &survey.Select{Message: label, Options: input.Items, Default: value}
+	case len(input.Items) > 0 && (value == "" || !input.Cached()):
+		return &survey.Select{Message: label, Options: input.Items}
+	default:
+		return &survey.Input{Message: label, Default: value}
+	}
+}
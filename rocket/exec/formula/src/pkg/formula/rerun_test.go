@@ -0,0 +1,125 @@
+package formula
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"dennis"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+func TestInputs_rerun(t *testing.T) {
+	tempHome(t)
+	entry := dennis.HistoryEntry{ID: "test-07", Context: "QA", Command: "rit coffee"}
+	if err := dennis.DefaultHistory().Append(entry); err != nil {
+		t.Fatal(err)
+	}
+	client := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/executions/test-06":
+			_, _ = w.Write([]byte(`{"status": "Ready", "content": {"command": "rit coffee",
+				"formulaInputs": [{"name": "name", "value": "Dennis"}, {"name": "coffee_type", "value": "latte"}, {"name": "ip", "value": "10.0.0.1"}]}}`))
+		case "/executions/test-07":
+			_, _ = w.Write([]byte(`{"status": "Ready", "content": {"formulaInputs": [{"name": "name", "value": "Dennis"}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		in      Inputs
+		want    Inputs
+		wantErr bool
+	}{
+		{
+			name: "inputs of the execution",
+			in:   Inputs{Rerun: "test-06", Context: "DEV", Values: Values{"coffee_type": "espresso"}},
+			want: Inputs{Rerun: "test-06", Context: "DEV", Command: "rit coffee", Values: Values{"name": "Dennis", "coffee_type": "espresso"}},
+		},
+		{
+			name: "context and command of the history",
+			in:   Inputs{Rerun: "test-07"},
+			want: Inputs{Rerun: "test-07", Context: "QA", Command: "rit coffee", Values: Values{"name": "Dennis"}},
+		},
+		{name: "unknown context", in: Inputs{Rerun: "test-06"}, wantErr: true},
+		{name: "unknown execution", in: Inputs{Rerun: "test-05", Context: "DEV"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.in.rerun(client, coffeeFormulas, false)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("rerun() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rerun() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditPrompt(t *testing.T) {
+	tests := []struct {
+		name  string
+		input dennis.Input
+		value string
+		want  survey.Prompt
+	}{
+		{
+			name:  "credential",
+			input: dennis.Input{Name: "token", Type: "CREDENTIAL_GITHUB_TOKEN"},
+			value: "secret",
+			want:  &survey.Password{Message: "token (empty keeps the previous value)"},
+		},
+		{
+			name:  "bool",
+			input: dennis.Input{Name: "delivery", Type: "bool"},
+			value: "TRUE",
+			want:  &survey.Select{Message: "delivery", Options: []string{"false", "true"}, Default: "true"},
+		},
+		{
+			name:  "not a bool",
+			input: dennis.Input{Name: "delivery", Type: "bool"},
+			value: "yes",
+			want:  &survey.Select{Message: "delivery", Options: []string{"false", "true"}},
+		},
+		{
+			name:  "item",
+			input: dennis.Input{Name: "coffee_type", Type: "text", Items: dennis.Items{"espresso", "latte"}},
+			value: "latte",
+			want:  &survey.Select{Message: "coffee_type", Options: []string{"espresso", "latte"}, Default: "latte"},
+		},
+		{
+			name:  "cached value not an item",
+			input: dennis.Input{Name: "coffee_type", Type: "text", Items: dennis.Items{"espresso", "latte"}, Cache: &dennis.Cache{Active: true}},
+			value: "mocha",
+			want:  &survey.Input{Message: "coffee_type", Default: "mocha"},
+		},
+		{
+			name:  "no longer an item",
+			input: dennis.Input{Name: "coffee_type", Type: "text", Items: dennis.Items{"espresso", "latte"}},
+			value: "mocha",
+			want:  &survey.Select{Message: "coffee_type", Options: []string{"espresso", "latte"}},
+		},
+		{
+			name:  "no previous item",
+			input: dennis.Input{Name: "coffee_type", Type: "text", Items: dennis.Items{"espresso", "latte"}},
+			want:  &survey.Select{Message: "coffee_type", Options: []string{"espresso", "latte"}},
+		},
+		{
+			name:  "text",
+			input: dennis.Input{Name: "name", Label: "Name: ", Type: "text"},
+			value: "Dennis",
+			want:  &survey.Input{Message: "Name: ", Default: "Dennis"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := editPrompt(tt.input, tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("editPrompt() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
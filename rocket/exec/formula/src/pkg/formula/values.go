@@ -0,0 +1,90 @@
+package formula
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"dennis"
+)
+
+const (
+	inputEnvPrefix = "DENNIS_INPUT_"
+	// inputFileEnv has the input prefix but is the invocation file.
+	inputFileEnv = "DENNIS_INPUT_FILE"
+	// inputsEnv holds comma separated name=value pairs, the way the inputs are
+	// given under rit, which only passes the inputs of config.json.
+	inputsEnv = "DENNIS_INPUTS"
+)
+
+// Values are remote input values given up front, by name.
+type Values map[string]string
+
+// String implements flag.Value.
+func (v Values) String() string {
+	pairs := make([]string, 0, len(v))
+	for name, value := range v {
+		pairs = append(pairs, name+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// Set implements flag.Value, parsing a name=value pair.
+func (v Values) Set(s string) error {
+	i := strings.Index(s, "=")
+	if i <= 0 {
+		return fmt.Errorf("invalid input %q, use name=value", s)
+	}
+	v[s[:i]] = s[i+1:]
+	return nil
+}
+
+// EnvValues returns the remote inputs given as DENNIS_INPUT_<NAME> env vars,
+// e.g. DENNIS_INPUT_REGION=us-east-1 for the input region, and as the pairs of
+// DENNIS_INPUTS, e.g. region=us-east-1,size=large, unless it is "none". Names
+// are kept as given, to be matched to the inputs of the formula regardless of
+// case.
+func EnvValues() (Values, error) {
+	v := Values{}
+	for _, pair := range strings.Split(dennis.EnvInput(inputsEnv, "none"), ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		if err := v.Set(pair); err != nil {
+			return nil, fmt.Errorf("%s: %w", inputsEnv, err)
+		}
+	}
+
+	for _, env := range os.Environ() {
+		if !strings.HasPrefix(env, inputEnvPrefix) || strings.HasPrefix(env, inputFileEnv+"=") {
+			continue
+		}
+		pair := strings.SplitN(strings.TrimPrefix(env, inputEnvPrefix), "=", 2)
+		if len(pair) == 2 && pair[0] != "" {
+			v[pair[0]] = pair[1]
+		}
+	}
+	return v, nil
+}
+
+// match returns the values named after the inputs of the formula, matching
+// the names regardless of case when there is no input with the exact name,
+// e.g. REGION for the input region. Names matching no input are kept as
+// given, to be reported as unknown.
+func (v Values) match(form dennis.Formula) map[string]string {
+	values := make(map[string]string, len(v))
+	for name, value := range v {
+		if _, ok := form.Inputs.Find(name); !ok {
+			for _, input := range form.Inputs {
+				if strings.EqualFold(input.Name, name) {
+					name = input.Name
+					break
+				}
+			}
+		}
+		values[name] = value
+	}
+	return values
+}
@@ -0,0 +1,87 @@
+package formula
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestValues_Set(t *testing.T) {
+	tests := []struct {
+		name    string
+		pair    string
+		want    Values
+		wantErr bool
+	}{
+		{name: "pair", pair: "region=sa-east-1", want: Values{"region": "sa-east-1"}},
+		{name: "value with =", pair: "filter=a=b", want: Values{"filter": "a=b"}},
+		{name: "empty value", pair: "region=", want: Values{"region": ""}},
+		{name: "no value", pair: "region", want: Values{}, wantErr: true},
+		{name: "no name", pair: "=sa-east-1", want: Values{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := Values{}
+			if err := v.Set(tt.pair); (err != nil) != tt.wantErr || !reflect.DeepEqual(v, tt.want) {
+				t.Errorf("Set() = %v, %v, want %v, wantErr %v", v, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEnvValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		inputs  string
+		want    Values
+		wantErr bool
+	}{
+		{name: "placeholder", inputs: "none", want: Values{"REGION": "sa-east-1", "coffee_type": "latte"}},
+		{name: "pairs", inputs: "size=large, region=us-east-1", want: Values{"REGION": "sa-east-1", "coffee_type": "latte", "size": "large", "region": "us-east-1"}},
+		{name: "env var wins", inputs: "coffee_type=mocha", want: Values{"REGION": "sa-east-1", "coffee_type": "latte"}},
+		{name: "invalid pair", inputs: "size", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := map[string]string{
+				"DENNIS_INPUT_REGION":      "sa-east-1",
+				"DENNIS_INPUT_coffee_type": "latte",
+				"DENNIS_INPUT_":            "nameless",
+				"DENNIS_INPUT_FILE":        "invocation.yml",
+				"DENNIS_INPUTS":            tt.inputs,
+			}
+			for key, value := range env {
+				os.Setenv(key, value)
+				defer os.Unsetenv(key)
+			}
+
+			got, err := EnvValues()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EnvValues() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EnvValues() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValues_match(t *testing.T) {
+	form := coffeeFormulas.Formulas[0]
+	tests := []struct {
+		name   string
+		values Values
+		want   map[string]string
+	}{
+		{name: "exact", values: Values{"name": "Dennis", "Size": "large"}, want: map[string]string{"name": "Dennis", "Size": "large"}},
+		{name: "env var", values: Values{"NAME": "Dennis", "COFFEE_TYPE": "latte", "SIZE": "large"}, want: map[string]string{"name": "Dennis", "coffee_type": "latte", "Size": "large"}},
+		{name: "unknown", values: Values{"MILK": "oat"}, want: map[string]string{"MILK": "oat"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.values.match(form); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package formula
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"dennis"
+
+	"github.com/ZupIT/ritchie-cli/pkg/prompt"
+)
+
+func (in Inputs) waitOptions(timeout time.Duration) dennis.WaitOptions {
+	opts := dennis.DefaultWaitOptions()
+	opts.Timeout = timeout
+	if in.Timeout > 0 {
+		opts.Timeout = in.Timeout
+	}
+	return opts
+}
+
+// awaitExecution waits for the execution printing its output as it arrives.
+// A dot is printed per poll until there is some output, or all along when the
+// server does not stream logs. It reports whether the output was streamed.
+func (in Inputs) awaitExecution(goCtx context.Context, client *dennis.Client, ctx, cmdID string) (dennis.ExecutionResponse, bool, error) {
+	prompt.Info("Awaiting execution...")
+	logs := &dennis.LogTail{Client: client, Context: ctx, ID: cmdID}
+	dots := false
+	progress := func(chunks []dennis.LogChunk) {
+		switch {
+		case len(chunks) > 0 && dots:
+			fmt.Println()
+			dots = false
+		case len(chunks) == 0 && !logs.Streamed():
+			fmt.Print(".")
+			dots = true
+		}
+		logs.Print(chunks)
+	}
+
+	opts := in.waitOptions(dennis.DefaultWaitOptions().Timeout)
+	opts.OnPoll = func(resp dennis.ExecutionResponse, err error) {
+		if err == nil && resp.Ready() {
+			return
+		}
+		progress(logs.Poll(goCtx, err))
+	}
+
+	execResp, err := client.WaitExecution(goCtx, ctx, cmdID, opts)
+	if err == nil {
+		// the output written between the last poll and the end
+		if chunks := logs.Poll(goCtx, nil); len(chunks) > 0 {
+			progress(chunks)
+		}
+	}
+	if dots {
+		fmt.Println()
+	}
+	if err == nil {
+		prompt.Success("done")
+	}
+	return execResp, logs.Streamed(), err
+}
+
+// printExecution prints the result of the execution, leaving out its output
+// when it was streamed.
+func printExecution(cmdID string, execResp dennis.ExecutionResponse, streamed bool) {
+	fmt.Println()
+	fmt.Println("-----------------------")
+
+	cont := execResp.Content
+
+	fmt.Print("Execution ID: ")
+	prompt.Info(cmdID)
+
+	fmt.Print("Execution time: ")
+	prompt.Info(cont.Duration().String())
+
+	fmt.Print("User: ")
+	prompt.Info(cont.User)
+	fmt.Println()
+
+	inputs, _ := json.Marshal(cont.FormulaInputs)
+	if inputs != nil {
+		fmt.Println("inputs:")
+		prompt.Info(string(inputs))
+	}
+	if !streamed {
+		fmt.Println()
+		fmt.Println("stdout:")
+		prompt.Info(cont.FormulaOut)
+		fmt.Println()
+		fmt.Println("stderr:")
+		prompt.Info(cont.FormulaErr)
+	}
+	fmt.Println("-----------------------")
+}
@@ -0,0 +1,84 @@
+package dennis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// CredentialInfo describes a credential set in a context. Dennis never sends
+// the secret values back, only the names of the keys.
+type CredentialInfo struct {
+	Service   string    `json:"service,omitempty"`
+	Keys      []string  `json:"keys,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+}
+
+type CredentialsResponse struct {
+	Credentials []CredentialInfo `json:"credentials,omitempty"`
+}
+
+// ErrVerifyUnsupported is returned when the server cannot verify credentials.
+var ErrVerifyUnsupported = errors.New("verifying credentials is not supported by the server")
+
+// VerifyResponse is the answer of POST /credentials/verify, with Message
+// telling what failed when the credential is not valid.
+type VerifyResponse struct {
+	Valid   bool   `json:"valid"`
+	Message string `json:"message,omitempty"`
+}
+
+// CredentialOutput is a credential of a context as written by the output
+// formats.
+type CredentialOutput struct {
+	Context   string   `json:"context" yaml:"context"`
+	Provider  string   `json:"provider" yaml:"provider"`
+	Keys      []string `json:"keys" yaml:"keys"`
+	UpdatedAt string   `json:"updatedAt,omitempty" yaml:"updatedAt,omitempty"`
+}
+
+// NewCredentialOutput returns the output of a credential of the context,
+// with its keys sorted and the last update in RFC3339 UTC.
+func NewCredentialOutput(ctx string, info CredentialInfo) CredentialOutput {
+	keys := append([]string{}, info.Keys...)
+	sort.Strings(keys)
+	out := CredentialOutput{Context: ctx, Provider: info.Service, Keys: keys}
+	if !info.UpdatedAt.IsZero() {
+		out.UpdatedAt = info.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+	return out
+}
+
+// WriteCredentials writes the credentials in the given format, a row per
+// credential for text and table.
+func WriteCredentials(w io.Writer, format string, outs []CredentialOutput) error {
+	switch format {
+	case OutputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(outs)
+	case OutputYAML:
+		b, err := yaml.Marshal(outs)
+		if err != nil {
+			return fmt.Errorf("error encoding credentials: %w", err)
+		}
+		_, err = w.Write(b)
+		return err
+	case OutputText, OutputTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "CONTEXT\tPROVIDER\tKEYS\tUPDATED")
+		for _, out := range outs {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", out.Context, out.Provider, strings.Join(out.Keys, ", "), out.UpdatedAt)
+		}
+		return tw.Flush()
+	default:
+		return CheckOutput(format)
+	}
+}
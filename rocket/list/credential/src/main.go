@@ -0,0 +1,23 @@
+package main
+
+import (
+	"dennis"
+	"flag"
+	"os"
+	"rocket/credentials/pkg/credentials"
+)
+
+func main() {
+	context := flag.String("context", dennis.EnvInput("DENNIS_CONTEXT", "all"), "context of the credentials, every context when empty")
+	provider := flag.String("provider", dennis.EnvInput("DENNIS_PROVIDER", "all"), "provider of the credentials, e.g. github")
+	output := flag.String("output", dennis.EnvString("DENNIS_OUTPUT", "text"), "format of the result: text, json, yaml or table")
+	flag.Parse()
+
+	credentials.Inputs{
+		Username: os.Getenv("USERNAME"),
+		Password: os.Getenv("PASSWORD"),
+		Context:  *context,
+		Provider: *provider,
+		Output:   *output,
+	}.Run()
+}
@@ -0,0 +1,88 @@
+package credentials
+
+import (
+	"fmt"
+	"os"
+
+	"dennis"
+
+	"github.com/ZupIT/ritchie-cli/pkg/prompt"
+)
+
+type Inputs struct {
+	Username string
+	Password string
+	Context  string
+	Provider string
+	Output   string
+}
+
+func (in Inputs) Run() {
+	if err := dennis.CheckOutput(in.Output); err != nil {
+		prompt.Error(err.Error())
+		os.Exit(dennis.ExitCode(err))
+	}
+	result := dennis.RedirectOutput(in.Output)
+
+	host, err := dennis.ResolveHost()
+	if err != nil {
+		prompt.Error(err.Error())
+		os.Exit(dennis.ExitCode(err))
+	}
+	client := dennis.NewClient(host, in.Username, in.Password)
+
+	// login
+	prompt.Info("Authenticating...")
+	if err := client.Authenticate(); err != nil {
+		prompt.Error(err.Error())
+		os.Exit(dennis.ExitCode(err))
+	}
+	prompt.Success("done")
+
+	contexts := []string{in.Context}
+	if in.Context == "" {
+		prompt.Info("Obtaining contexts...")
+		formulasResp, err := client.ListFormulas()
+		if err != nil {
+			prompt.Error(err.Error())
+			os.Exit(dennis.ExitCode(err))
+		}
+		prompt.Success("done")
+		contexts = formulasResp.Contexts.Names()
+	}
+
+	prompt.Info("Listing credentials...")
+	outs, err := list(client, contexts, in.Provider)
+	if err != nil {
+		prompt.Error(err.Error())
+		os.Exit(dennis.ExitCode(err))
+	}
+	prompt.Success("done")
+
+	if len(outs) == 0 && in.Output == dennis.OutputText {
+		prompt.Info("No credentials found")
+		return
+	}
+	if err := dennis.WriteCredentials(result, in.Output, outs); err != nil {
+		prompt.Error(err.Error())
+		os.Exit(dennis.ExitFailure)
+	}
+}
+
+// list returns the credentials of every context, in the order of the
+// contexts, keeping only the ones of the provider when given.
+func list(client *dennis.Client, contexts []string, provider string) ([]dennis.CredentialOutput, error) {
+	outs := []dennis.CredentialOutput{}
+	for _, ctx := range contexts {
+		resp, err := client.ListCredentials(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("context %s: %w", ctx, err)
+		}
+		for _, info := range resp.Credentials {
+			if provider == "" || info.Service == provider {
+				outs = append(outs, dennis.NewCredentialOutput(ctx, info))
+			}
+		}
+	}
+	return outs, nil
+}
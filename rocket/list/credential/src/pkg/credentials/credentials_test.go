@@ -0,0 +1,49 @@
+package credentials
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"dennis"
+)
+
+func TestList(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("x-ctx") {
+		case "DEV":
+			_, _ = w.Write([]byte(`{"credentials":[{"service":"github","keys":["token","username"]},{"service":"aws","keys":["accesskeyid"]}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"credentials":[{"service":"github","keys":["token"]}]}`))
+		}
+	}))
+	defer srv.Close()
+	client := dennis.NewClient(srv.URL, "user", "pass")
+	client.Tokens = dennis.TokenStore{}
+
+	tests := []struct {
+		name     string
+		provider string
+		want     []string
+	}{
+		{name: "every provider", want: []string{"DEV/github", "DEV/aws", "QA/github"}},
+		{name: "github", provider: "github", want: []string{"DEV/github", "QA/github"}},
+		{name: "unset provider", provider: "gcp"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outs, err := list(client, []string{"DEV", "QA"}, tt.provider)
+			if err != nil {
+				t.Fatalf("list() error = %v", err)
+			}
+			if len(outs) != len(tt.want) {
+				t.Fatalf("list() = %+v, want %v", outs, tt.want)
+			}
+			for i, out := range outs {
+				if got := out.Context + "/" + out.Provider; got != tt.want[i] {
+					t.Errorf("list()[%d] = %s, want %s", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
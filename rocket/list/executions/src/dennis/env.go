@@ -0,0 +1,46 @@
+package dennis
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/mattn/go-isatty"
+)
+
+// EnvString returns the value of the env var, or def when it is not set.
+func EnvString(key, def string) string {
+	if s := os.Getenv(key); s != "" {
+		return s
+	}
+	return def
+}
+
+// EnvInput returns the value of the env var of an input declared in
+// config.json, empty when it is the placeholder the input defaults to. rit
+// requires a text input without a default, so optional inputs default to a
+// placeholder such as "pick" or "none" that stands for no value.
+func EnvInput(key, placeholder string) string {
+	if s := os.Getenv(key); s != placeholder {
+		return s
+	}
+	return ""
+}
+
+// EnvDuration returns the env var parsed as a duration, e.g. 5m, or zero.
+func EnvDuration(key string) time.Duration {
+	d, _ := time.ParseDuration(os.Getenv(key))
+	return d
+}
+
+// EnvInt returns the env var parsed as an integer, or zero.
+func EnvInt(key string) int {
+	i, _ := strconv.Atoi(os.Getenv(key))
+	return i
+}
+
+// IsTerminal reports whether stdin is a TTY, so missing values can be prompted.
+func IsTerminal() bool {
+	fd := os.Stdin.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"dennis"
+	"flag"
+	"os"
+	"rocket/executions/pkg/executions"
+)
+
+func main() {
+	context := flag.String("context", dennis.EnvInput("DENNIS_CONTEXT", "all"), "context of the executions, every context when empty")
+	command := flag.String("command", dennis.EnvInput("DENNIS_COMMAND", "all"), "command of the remote formula, e.g. \"rit aws list bucket\"")
+	status := flag.String("status", dennis.EnvInput("DENNIS_STATUS", "all"), "status of the executions, e.g. Ready")
+	user := flag.String("user", dennis.EnvString("DENNIS_USER", "me"), "user who ran the executions, me for the logged-in user or all for every user")
+	since := flag.String("since", dennis.EnvInput("DENNIS_SINCE", "none"), "executions started after a date, a time or a duration ago, e.g. 24h")
+	until := flag.String("until", dennis.EnvInput("DENNIS_UNTIL", "none"), "executions started before a date, a time or a duration ago")
+	page := flag.Int("page", dennis.EnvInt("DENNIS_PAGE"), "page of the executions, starting at 1")
+	size := flag.Int("size", dennis.EnvInt("DENNIS_SIZE"), "executions per page, 20 by default")
+	sort := flag.String("sort", dennis.EnvString("DENNIS_SORT", "desc"), "order by start time: desc or asc")
+	output := flag.String("output", dennis.EnvString("DENNIS_OUTPUT", "text"), "format of the result: text, json, yaml or table")
+	flag.Parse()
+
+	executions.Inputs{
+		Username: os.Getenv("USERNAME"),
+		Password: os.Getenv("PASSWORD"),
+		Context:  *context,
+		Command:  *command,
+		Status:   *status,
+		User:     *user,
+		Since:    *since,
+		Until:    *until,
+		Page:     *page,
+		Size:     *size,
+		Sort:     *sort,
+		Output:   *output,
+	}.Run()
+}
@@ -0,0 +1,208 @@
+package executions
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"dennis"
+
+	"github.com/ZupIT/ritchie-cli/pkg/prompt"
+)
+
+// User filters standing for the logged-in user, the default, and for every
+// user.
+const (
+	currentUser = "me"
+	allUsers    = "all"
+)
+
+type Inputs struct {
+	Username string
+	Password string
+	Context  string
+	Command  string
+	Status   string
+	User     string
+	Since    string
+	Until    string
+	Page     int
+	Size     int
+	Sort     string
+	Output   string
+}
+
+func (in Inputs) Run() {
+	if err := dennis.CheckOutput(in.Output); err != nil {
+		prompt.Error(err.Error())
+		os.Exit(dennis.ExitCode(err))
+	}
+	result := dennis.RedirectOutput(in.Output)
+
+	filter, err := in.filter(time.Now())
+	if err != nil {
+		prompt.Error(err.Error())
+		os.Exit(dennis.ExitCode(err))
+	}
+
+	host, err := dennis.ResolveHost()
+	if err != nil {
+		prompt.Error(err.Error())
+		os.Exit(dennis.ExitCode(err))
+	}
+	client := dennis.NewClient(host, in.Username, in.Password)
+
+	// login
+	prompt.Info("Authenticating...")
+	if err := client.Authenticate(); err != nil {
+		prompt.Error(err.Error())
+		os.Exit(dennis.ExitCode(err))
+	}
+	prompt.Success("done")
+
+	contexts := []string{in.Context}
+	if in.Context == "" {
+		prompt.Info("Obtaining contexts...")
+		formulasResp, err := client.ListFormulas()
+		if err != nil {
+			prompt.Error(err.Error())
+			os.Exit(dennis.ExitCode(err))
+		}
+		prompt.Success("done")
+		contexts = formulasResp.Contexts.Names()
+	}
+
+	prompt.Info("Listing executions...")
+	outs, total, err := list(client, contexts, filter)
+	if err != nil {
+		prompt.Error(err.Error())
+		os.Exit(dennis.ExitCode(err))
+	}
+	prompt.Success("done")
+
+	if len(outs) == 0 && in.Output == dennis.OutputText {
+		prompt.Info("No executions found")
+		return
+	}
+	if err := dennis.WriteExecutions(result, in.Output, outs); err != nil {
+		prompt.Error(err.Error())
+		os.Exit(dennis.ExitFailure)
+	}
+	if in.Output == dennis.OutputText && total > len(outs) {
+		prompt.Info(fmt.Sprintf("Page %s: %d of %d executions. Use -page to see the others.", filter.Query().Get("page"), len(outs), total))
+	}
+}
+
+// filter returns the execution filter of the inputs, parsing the time range
+// relative to now. The executions of the logged-in user are listed unless
+// another user, or all of them, is asked for.
+func (in Inputs) filter(now time.Time) (dennis.ExecutionFilter, error) {
+	filter := dennis.ExecutionFilter{
+		Command: in.Command,
+		Status:  in.Status,
+		User:    in.User,
+		Page:    in.Page,
+		Size:    in.Size,
+	}
+	switch in.User {
+	case "", currentUser:
+		filter.User = in.Username
+	case allUsers:
+		filter.User = ""
+	}
+
+	switch in.Sort {
+	case "", "desc":
+	case "asc":
+		filter.Ascending = true
+	default:
+		return filter, fmt.Errorf("invalid sort %q, use desc or asc", in.Sort)
+	}
+
+	var err error
+	if filter.Since, err = parseTime(in.Since, now); err != nil {
+		return filter, fmt.Errorf("invalid since: %w", err)
+	}
+	if filter.Until, err = parseTime(in.Until, now); err != nil {
+		return filter, fmt.Errorf("invalid until: %w", err)
+	}
+	return filter, nil
+}
+
+// parseTime parses a RFC3339 time, a date as 2006-01-02 or a duration before
+// now, e.g. 24h. An empty string is the zero time.
+func parseTime(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("%q is not a time, a date or a duration", s)
+}
+
+// list returns a page of the executions of every context selected by the
+// filter, merged and sorted by start time, and how many executions match in
+// total. Each context is asked for every execution up to the end of the page,
+// as any of them may fall in it once merged, and the page is cut afterwards.
+func list(client *dennis.Client, contexts []string, filter dennis.ExecutionFilter) ([]dennis.ExecutionOutput, int, error) {
+	page, size := filter.Page, filter.Size
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = dennis.DefaultPageSize
+	}
+	upto := filter
+	upto.Page, upto.Size = 1, page*size
+
+	type execution struct {
+		ctx  string
+		resp dennis.ExecutionResponse
+	}
+	var execs []execution
+	total := 0
+	for _, ctx := range contexts {
+		resp, err := client.ListExecutions(ctx, upto)
+		if err != nil {
+			return nil, 0, fmt.Errorf("context %s: %w", ctx, err)
+		}
+
+		if resp.Total > len(resp.Executions) {
+			total += resp.Total
+		} else {
+			total += len(resp.Executions)
+		}
+		for _, e := range resp.Executions {
+			execs = append(execs, execution{ctx: ctx, resp: e})
+		}
+	}
+
+	sort.SliceStable(execs, func(i, j int) bool {
+		a, b := execs[i].resp.Content.StartTime.Time(), execs[j].resp.Content.StartTime.Time()
+		if filter.Ascending {
+			return a.Before(b)
+		}
+		return a.After(b)
+	})
+
+	start, end := (page-1)*size, page*size
+	if start > len(execs) {
+		start = len(execs)
+	}
+	if end > len(execs) {
+		end = len(execs)
+	}
+	outs := make([]dennis.ExecutionOutput, 0, end-start)
+	for _, e := range execs[start:end] {
+		outs = append(outs, dennis.NewExecutionOutput(e.ctx, e.resp))
+	}
+	return outs, total, nil
+}
@@ -0,0 +1,142 @@
+package executions
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"dennis"
+)
+
+func TestInputs_filter(t *testing.T) {
+	now := time.Date(2020, 8, 7, 14, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		in        Inputs
+		wantSince time.Time
+		wantUntil time.Time
+		wantAsc   bool
+		wantErr   bool
+	}{
+		{name: "no range"},
+		{
+			name:      "duration ago",
+			in:        Inputs{Since: "24h", Until: "30m"},
+			wantSince: now.Add(-24 * time.Hour),
+			wantUntil: now.Add(-30 * time.Minute),
+		},
+		{
+			name:      "RFC3339",
+			in:        Inputs{Since: "2020-08-07T13:59:44Z", Sort: "asc"},
+			wantSince: time.Date(2020, 8, 7, 13, 59, 44, 0, time.UTC),
+			wantAsc:   true,
+		},
+		{
+			name:      "date",
+			in:        Inputs{Until: "2020-08-06"},
+			wantUntil: time.Date(2020, 8, 6, 0, 0, 0, 0, time.Local),
+		},
+		{name: "invalid time", in: Inputs{Since: "yesterday"}, wantErr: true},
+		{name: "invalid sort", in: Inputs{Sort: "startTime"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.in.filter(now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("filter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !got.Since.Equal(tt.wantSince) || !got.Until.Equal(tt.wantUntil) || got.Ascending != tt.wantAsc {
+				t.Errorf("filter() = %+v", got)
+			}
+		})
+	}
+}
+
+func TestInputs_filter_user(t *testing.T) {
+	tests := []struct {
+		user string
+		want string
+	}{
+		{user: "", want: "john"},
+		{user: "me", want: "john"},
+		{user: "all", want: ""},
+		{user: "mary", want: "mary"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.user, func(t *testing.T) {
+			got, err := Inputs{Username: "john", User: tt.user}.filter(time.Now())
+			if err != nil || got.User != tt.want {
+				t.Errorf("filter() user = %q, %v, want %q", got.User, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	start := time.Date(2020, 8, 7, 9, 0, 0, 0, time.UTC)
+	// the executions of the contexts interleave: DEV ran at 9:00, 9:02 and
+	// 9:04, QA at 9:01, 9:03, 9:05 and 9:10
+	minutes := map[string][]int{"DEV": {0, 2, 4}, "QA": {1, 3, 5, 10}}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Header.Get("x-ctx")
+		var execs []dennis.ExecutionResponse
+		for _, m := range minutes[ctx] {
+			e := dennis.ExecutionResponse{Status: "Ready"}
+			e.Content.ID = ctx + "-" + strconv.Itoa(m)
+			e.Content.StartTime = dennis.ExecTime(start.Add(time.Duration(m) * time.Minute))
+			execs = append(execs, e)
+		}
+		sort.Slice(execs, func(i, j int) bool {
+			if r.URL.Query().Get("order") == "asc" {
+				return execs[i].Content.StartTime.Unix() < execs[j].Content.StartTime.Unix()
+			}
+			return execs[i].Content.StartTime.Unix() > execs[j].Content.StartTime.Unix()
+		})
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+		from, to := (page-1)*size, page*size
+		if to > len(execs) {
+			to = len(execs)
+		}
+		_ = json.NewEncoder(w).Encode(dennis.ExecutionsResponse{Executions: execs[from:to], Total: len(execs)})
+	}))
+	defer srv.Close()
+	client := dennis.NewClient(srv.URL, "user", "pass")
+	client.Tokens = dennis.TokenStore{}
+
+	tests := []struct {
+		name   string
+		filter dennis.ExecutionFilter
+		want   []string
+	}{
+		{name: "first page", filter: dennis.ExecutionFilter{Size: 3}, want: []string{"QA-10", "QA-5", "DEV-4"}},
+		{name: "second page", filter: dennis.ExecutionFilter{Page: 2, Size: 3}, want: []string{"QA-3", "DEV-2", "QA-1"}},
+		{name: "last page", filter: dennis.ExecutionFilter{Page: 3, Size: 3}, want: []string{"DEV-0"}},
+		{name: "past the end", filter: dennis.ExecutionFilter{Page: 4, Size: 3}},
+		{name: "ascending", filter: dennis.ExecutionFilter{Size: 2, Ascending: true}, want: []string{"DEV-0", "QA-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outs, total, err := list(client, []string{"DEV", "QA"}, tt.filter)
+			if err != nil {
+				t.Fatalf("list() error = %v", err)
+			}
+			var ids []string
+			for _, o := range outs {
+				ids = append(ids, o.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.want) || total != 7 {
+				t.Errorf("list() = %v, %d, want %v, 7", ids, total, tt.want)
+			}
+		})
+	}
+}
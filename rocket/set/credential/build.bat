@@ -11,8 +11,11 @@ SET DIST_LINUX_DIR=%BIN_FOLDER%\linux
 SET BIN_WIN=%BINARY_NAME%.exe
 SET BAT_FILE=%BIN_FOLDER%\run.bat
 SET SH_FILE=%BIN_FOLDER%\run.sh
+SET VENDOR=..\..\..\lib\vendor.sh
 
 :build
+    CALL :vendor_check
+    if %errorlevel% neq 0 exit /b %errorlevel%
     cd src
     mkdir %DIST_WIN_DIR%
     SET GO111MODULE=on
@@ -42,6 +45,11 @@ SET SH_FILE=%BIN_FOLDER%\run.sh
     echo "$(dirname "$0")"/linux/%BINARY_NAME% > %SH_FILE%
     GOTO DONE
 
+:vendor_check
+    if not exist %VENDOR% GOTO DONE
+    sh %VENDOR% -check
+    GOTO DONE
+
 :CP_DOCKER
     copy ..\Dockerfile %BIN_FOLDER%
     copy ..\set_umask.sh %BIN_FOLDER%
@@ -0,0 +1,81 @@
+package dennis
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	OperatorEqual    = "=="
+	OperatorNotEqual = "!="
+	OperatorIn       = "in"
+)
+
+// Condition makes an input apply only when the value of an earlier input,
+// Variable, compares to Value. With the in operator Value is a comma
+// separated list.
+type Condition struct {
+	Variable string `json:"variable,omitempty"`
+	Operator string `json:"operator,omitempty"`
+	Value    string `json:"value,omitempty"`
+}
+
+// Met reports whether the condition holds for the given values. A nil
+// condition always holds.
+func (c *Condition) Met(values map[string]string) bool {
+	if c == nil {
+		return true
+	}
+
+	value := values[c.Variable]
+	switch c.Operator {
+	case OperatorEqual:
+		return value == c.Value
+	case OperatorNotEqual:
+		return value != c.Value
+	case OperatorIn:
+		for _, item := range strings.Split(c.Value, ",") {
+			if strings.TrimSpace(item) == value {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// check validates the operator and the variable of the condition of the
+// input, which must be one of the inputs before it.
+func (c *Condition) check(input string, before Inputs) []string {
+	if c == nil {
+		return nil
+	}
+
+	var problems []string
+	if c.Operator != OperatorEqual && c.Operator != OperatorNotEqual && c.Operator != OperatorIn {
+		problems = append(problems, fmt.Sprintf("input %q has the unknown condition operator %q", input, c.Operator))
+	}
+	if _, ok := before.Find(c.Variable); !ok {
+		problems = append(problems, fmt.Sprintf("input %q has a condition on %q, which is not an input before it", input, c.Variable))
+	}
+	return problems
+}
+
+// Resolve returns the values of the inputs that apply, in the order of the
+// schema, the missing ones taking their default. Inputs whose condition does
+// not hold are left out, even when given.
+func (in Inputs) Resolve(given map[string]string) map[string]string {
+	values := map[string]string{}
+	for _, input := range in {
+		if !input.Condition.Met(values) {
+			continue
+		}
+		value, ok := given[input.Name]
+		if !ok {
+			value = input.Default
+		}
+		values[input.Name] = value
+	}
+	return values
+}
@@ -0,0 +1,91 @@
+package dennis
+
+import (
+	"context"
+	"errors"
+	"net/url"
+)
+
+// Exit codes of the rocket formulas, besides the status code of the remote
+// formula, which is passed through unless it falls in a range reserved for the
+// local failures: 64 to 79 and 124 to 130.
+const (
+	ExitOK          = 0
+	ExitFailure     = 1
+	ExitNotFound    = 66
+	ExitTransport   = 69
+	ExitRunning     = 75
+	ExitAuth        = 77
+	ExitRemote      = 79
+	ExitTimeout     = 124
+	ExitInterrupted = 130
+)
+
+// StatusError is returned when Dennis answers with an unexpected status code.
+type StatusError struct {
+	Status int
+	Msg    string
+}
+
+func (e *StatusError) Error() string {
+	return e.Msg
+}
+
+func statusError(status int, msg string) error {
+	return &StatusError{Status: status, Msg: msg}
+}
+
+// IsUnauthorized reports whether Dennis refused the credentials or the token.
+func IsUnauthorized(err error) bool {
+	var se *StatusError
+	return errors.As(err, &se) && (se.Status == 401 || se.Status == 403)
+}
+
+// IsNotFound reports whether Dennis did not find the requested resource.
+func IsNotFound(err error) bool {
+	var se *StatusError
+	return errors.As(err, &se) && se.Status == 404
+}
+
+// IsConflict reports whether Dennis refused the request for the current state
+// of the resource, e.g. canceling a finished execution.
+func IsConflict(err error) bool {
+	var se *StatusError
+	return errors.As(err, &se) && se.Status == 409
+}
+
+// ExitCode maps an error of the client to the process exit code.
+func ExitCode(err error) int {
+	var ue *url.Error
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, ErrTimeout):
+		return ExitTimeout
+	case errors.Is(err, context.Canceled):
+		return ExitInterrupted
+	case IsUnauthorized(err):
+		return ExitAuth
+	case IsNotFound(err):
+		return ExitNotFound
+	case errors.As(err, &ue):
+		return ExitTransport
+	default:
+		return ExitFailure
+	}
+}
+
+// StatusExitCode maps the status code of a remote formula to the process exit
+// code, passing it through when it fits in an exit code. The codes reserved
+// for the local failures become ExitRemote, so that a remote formula exiting
+// with 77 is not taken as an authorization failure; the status code itself is
+// still in the output of the execution.
+func StatusExitCode(statusCode int) int {
+	switch {
+	case statusCode < 0 || statusCode > 255:
+		return ExitFailure
+	case statusCode >= 64 && statusCode <= 79, statusCode >= 124 && statusCode <= 130:
+		return ExitRemote
+	}
+	return statusCode
+}
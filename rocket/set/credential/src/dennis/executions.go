@@ -0,0 +1,121 @@
+package dennis
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultPageSize is how many executions are listed per page when the filter
+// does not set one.
+const DefaultPageSize = 20
+
+// ExecutionFilter selects the executions of ListExecutions. Empty fields match
+// every execution. Since and Until bound the start time, Page starts at 1 and
+// executions are sorted by start time, newest first unless Ascending.
+type ExecutionFilter struct {
+	Command   string
+	Status    string
+	User      string
+	Since     time.Time
+	Until     time.Time
+	Page      int
+	Size      int
+	Ascending bool
+}
+
+type ExecutionsResponse struct {
+	Executions []ExecutionResponse `json:"executions"`
+	Total      int                 `json:"total,omitempty"`
+}
+
+// Query returns the query string sent to the server.
+func (f ExecutionFilter) Query() url.Values {
+	q := url.Values{}
+	if f.Command != "" {
+		q.Set("command", f.Command)
+	}
+	if f.Status != "" {
+		q.Set("status", f.Status)
+	}
+	if f.User != "" {
+		q.Set("user", f.User)
+	}
+	if !f.Since.IsZero() {
+		q.Set("since", strconv.FormatInt(f.Since.Unix(), 10))
+	}
+	if !f.Until.IsZero() {
+		q.Set("until", strconv.FormatInt(f.Until.Unix(), 10))
+	}
+	q.Set("page", strconv.Itoa(f.page()))
+	q.Set("size", strconv.Itoa(f.size()))
+	q.Set("sort", "startTime")
+	q.Set("order", f.order())
+	return q
+}
+
+// Match reports whether the execution is selected by the filter. The time
+// range is checked against the start time of the execution.
+func (f ExecutionFilter) Match(e ExecutionResponse) bool {
+	cont := e.Content
+	switch {
+	case f.Command != "" && cont.Command != f.Command:
+		return false
+	case f.Status != "" && !strings.EqualFold(e.Status, f.Status):
+		return false
+	case f.User != "" && cont.User != f.User:
+		return false
+	case !f.Since.IsZero() && cont.StartTime.Time().Before(f.Since):
+		return false
+	case !f.Until.IsZero() && cont.StartTime.Time().After(f.Until):
+		return false
+	}
+	return true
+}
+
+// Apply returns the executions selected by the filter, sorted by start time.
+// It guards against servers ignoring part of the query.
+func (f ExecutionFilter) Apply(execs []ExecutionResponse) []ExecutionResponse {
+	selected := make([]ExecutionResponse, 0, len(execs))
+	for _, e := range execs {
+		if f.Match(e) {
+			selected = append(selected, e)
+		}
+	}
+	f.Sort(selected)
+	return selected
+}
+
+// Sort sorts executions by start time in the order of the filter.
+func (f ExecutionFilter) Sort(execs []ExecutionResponse) {
+	sort.SliceStable(execs, func(i, j int) bool {
+		a, b := execs[i].Content.StartTime.Unix(), execs[j].Content.StartTime.Unix()
+		if f.Ascending {
+			return a < b
+		}
+		return a > b
+	})
+}
+
+func (f ExecutionFilter) page() int {
+	if f.Page < 1 {
+		return 1
+	}
+	return f.Page
+}
+
+func (f ExecutionFilter) size() int {
+	if f.Size < 1 {
+		return DefaultPageSize
+	}
+	return f.Size
+}
+
+func (f ExecutionFilter) order() string {
+	if f.Ascending {
+		return "asc"
+	}
+	return "desc"
+}
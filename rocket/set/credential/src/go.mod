@@ -3,7 +3,9 @@ module hello
 go 1.14
 
 require (
+	dennis v0.0.0-00010101000000-000000000000
+	github.com/AlecAivazis/survey/v2 v2.0.7
 	github.com/ZupIT/ritchie-cli v0.0.0-20200807200008-c4017a578d9c
-	github.com/google/uuid v1.1.1
-	github.com/gookit/color v1.2.5
 )
+
+replace dennis => ./dennis
@@ -14,5 +14,7 @@ func loadInputs() hello.Inputs {
 		Username: os.Getenv("USERNAME"),
 		Password: os.Getenv("PASSWORD"),
 		Provider: os.Getenv("PROVIDER"),
+		Verify:   os.Getenv("DENNIS_VERIFY") != "false",
+		Rotate:   os.Getenv("DENNIS_ROTATE") == "true",
 	}
 }
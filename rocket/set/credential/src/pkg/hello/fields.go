@@ -0,0 +1,162 @@
+package hello
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"dennis"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/ZupIT/ritchie-cli/pkg/prompt"
+)
+
+// typeCredential is the option to type the credential instead of importing
+// one found locally.
+const typeCredential = "Type the credential"
+
+// readFields prompts for the fields of the provider not given, offering to
+// import them from the config of other tools first, and reads the content of
+// file fields from the typed path. The generic provider then takes key/value
+// pairs until an empty key. Typed values are checked when verify is set.
+func readFields(p dennis.Provider, given map[string]string, verify bool) (map[string]string, error) {
+	values, err := importFields(p)
+	if err != nil {
+		return nil, fmt.Errorf("error importing credential: %w", err)
+	}
+	for name, value := range given {
+		values[name] = value
+	}
+
+	for _, field := range p.Fields {
+		if _, ok := values[field.Name]; ok {
+			continue
+		}
+
+		value, err := readField(field, verify)
+		if err != nil {
+			return nil, fmt.Errorf("error reading credential: %w", err)
+		}
+		values[field.Name] = value
+	}
+
+	if p.Generic() {
+		if err := readPairs(values); err != nil {
+			return nil, fmt.Errorf("error reading credential: %w", err)
+		}
+	}
+	return values, nil
+}
+
+// importFields offers the credentials of the provider found in the config of
+// other tools, e.g. the profiles of ~/.aws/credentials, and returns the fields
+// of the chosen one.
+func importFields(p dennis.Provider) (map[string]string, error) {
+	values := map[string]string{}
+	found := dennis.DefaultLocalSources().Find(p.Name)
+	if len(found) == 0 {
+		return values, nil
+	}
+
+	items := []string{typeCredential}
+	for _, c := range found {
+		items = append(items, "Import the "+c.String())
+	}
+	choice, err := prompt.NewSurveyList().List("Found local credentials of "+p.Name, items)
+	if err != nil {
+		return nil, err
+	}
+	for i, c := range found {
+		if items[i+1] == choice {
+			for name, value := range c.Fields {
+				values[name] = value
+			}
+		}
+	}
+	return values, nil
+}
+
+func readField(field dennis.Input, verify bool) (string, error) {
+	value := ""
+	switch field.Type {
+	case "password":
+		q := &survey.Password{Message: field.Label}
+		err := survey.AskOne(q, &value, survey.WithValidator(checker(field, verify)))
+		return value, err
+	case dennis.FieldFile:
+		q := &survey.Input{Message: field.Label}
+		err := survey.AskOne(q, &value, survey.WithValidator(func(ans interface{}) error {
+			s, _ := ans.(string)
+			_, err := readFile(s)
+			return err
+		}))
+		if err != nil {
+			return "", err
+		}
+		return readFile(value)
+	default:
+		q := &survey.Input{Message: field.Label}
+		err := survey.AskOne(q, &value, survey.WithValidator(checker(field, verify)))
+		return value, err
+	}
+}
+
+// readPairs prompts for keys and their secret values until an empty key.
+func readPairs(values map[string]string) error {
+	for {
+		key := ""
+		q := &survey.Input{Message: "Key (empty to finish)"}
+		if err := survey.AskOne(q, &key); err != nil {
+			return err
+		}
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil
+		}
+
+		value := ""
+		if err := survey.AskOne(&survey.Password{Message: "Value of " + key}, &value, survey.WithValidator(survey.Required)); err != nil {
+			return err
+		}
+		values[key] = value
+	}
+}
+
+// checker validates a typed field. Without verification it only requires the
+// required fields.
+func checker(field dennis.Input, verify bool) survey.Validator {
+	return func(ans interface{}) error {
+		switch {
+		case verify:
+			s, _ := ans.(string)
+			return field.Check(s)
+		case field.Required:
+			return survey.Required(ans)
+		}
+		return nil
+	}
+}
+
+// readFile returns the content of the file, expanding a leading ~ to the
+// home directory.
+func readFile(path string) (string, error) {
+	if path == "" {
+		return "", errors.New("Value is required")
+	}
+	if strings.HasPrefix(path, "~") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, path[1:])
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("error reading %s: %w", path, err)
+	}
+	return string(b), nil
+}
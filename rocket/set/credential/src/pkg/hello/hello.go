@@ -1,350 +1,146 @@
 package hello
 
 import (
-	"bytes"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
-	"net/http"
 	"os"
-	"strconv"
 	"time"
 
-	"github.com/ZupIT/ritchie-cli/pkg/prompt"
-)
+	"dennis"
 
-const (
-	host = "https://dennis.devdennis.zup.io"
+	"github.com/ZupIT/ritchie-cli/pkg/prompt"
 )
 
 type Inputs struct {
-	Username         string
-	Password         string
-	Provider         string
-	ProviderUsername string
-	ProviderSecret   string
-}
-
-type loginRequest struct {
-	Username string `json:"username,omitempty"`
-	Password string `json:"password,omitempty"`
-}
-
-type loginResponse struct {
-	Token string `json:"token,omitempty"`
-	TTL   int64  `json:"ttl,omitempty"`
-}
-
-type formulasResponse struct {
-	Contexts contexts `json:"contexts,omitempty"`
-	Formulas formulas `json:"formulas,omitempty"`
-}
-
-type context struct {
-	Name string `json:"name,omitempty"`
-}
-
-type contexts []context
-
-type formula struct {
-	Command string `json:"command,omitempty"`
-	Inputs  inputs `json:"inputs,omitempty"`
-}
-
-type formulas []formula
-
-type input struct {
-	Name    string `json:"name,omitempty"`
-	Label   string `json:"label,omitempty"`
-	Type    string `json:"type,omitempty"`
-	Items   items  `json:"items,omitempty"`
-	Default string `json:"default,omitempty"`
-	Value   string `json:"value,omitempty"`
-}
-
-type items []string
-
-type inputs []input
-
-type commandRequest struct {
-	ID      string `json:"id,omitempty"`
-	Command string `json:"command,omitempty"`
-	Inputs  inputs `json:"inputs,omitempty"`
-}
-
-type executionResponse struct {
-	Status  string  `json:"status,omitempty"`
-	Content content `json:"content,omitempty"`
-}
-
-type content struct {
-	ID         string   `json:"id,omitempty"`
-	StatusCode int      `json:"statusCode,omitempty"`
-	User       string   `json:"user,omitempty"`
-	StartTime  ExecTime `json:"startTime,omitempty"`
-	EndTime    ExecTime `json:"endTime,omitempty"`
-	FormulaErr string   `json:"formulaErr,omitempty"`
-	FormulaOut string   `json:"formulaOutput,omitempty"`
-}
-
-type ExecTime time.Time
-
-func (t ExecTime) MarshalJSON() ([]byte, error) {
-	return []byte(strconv.FormatInt(time.Time(t).Unix(), 10)), nil
-}
-
-func (t *ExecTime) UnmarshalJSON(s []byte) (err error) {
-	r := string(s)
-	q, err := strconv.ParseInt(r, 10, 64)
-	if err != nil {
-		return err
-	}
-	*(*time.Time)(t) = time.Unix(q, 0)
-	return nil
-}
-
-func (t ExecTime) Unix() int64 {
-	return time.Time(t).Unix()
-}
-
-func (t ExecTime) Time() time.Time {
-	return time.Time(t).UTC()
-}
-
-func (t ExecTime) String() string {
-	return t.Time().String()
-}
-
-func (t ExecTime) Sub(o ExecTime) time.Duration {
-	return time.Time(t).Sub(time.Time(o))
+	Username string
+	Password string
+	Provider string
+	Fields   map[string]string
+	Verify   bool
+	Rotate   bool
 }
 
 func (in Inputs) Run() {
-
-	text := prompt.NewSurveyText()
-	pass := prompt.NewSurveyPassword()
-
-	var username string
-	var secret string
-	if in.Provider == "github" {
-		username, _ = text.Text("Username", true)
-		secret, _ = pass.Password("Token")
-
-	} else {
-		username, _ = text.Text("AccessKeyID", true)
-		secret, _ = pass.Password("SecretAccessKey")
-	}
-
-	in.ProviderUsername = username
-	in.ProviderSecret = secret
-
-	// login
-	loginResp, err := in.login()
+	provider, err := dennis.FindProvider(in.Provider)
 	if err != nil {
 		prompt.Error(err.Error())
-		os.Exit(1)
+		os.Exit(dennis.ExitFailure)
 	}
 
-	// formulas e context
-	formulasResp, err := in.formulas(loginResp.Token)
+	host, err := dennis.ResolveHost()
 	if err != nil {
 		prompt.Error(err.Error())
-		os.Exit(1)
+		os.Exit(dennis.ExitCode(err))
 	}
 
-	list := prompt.NewSurveyList()
-
-	ctxList := make([]string, len(formulasResp.Contexts))
-	for i, c := range formulasResp.Contexts {
-		ctxList[i] = c.Name
-	}
-	ctx, err := list.List("Select a context", ctxList)
+	in.Fields, err = readFields(provider, in.Fields, in.Verify)
 	if err != nil {
 		prompt.Error(err.Error())
-		os.Exit(1)
+		os.Exit(dennis.ExitFailure)
 	}
 
-	// set credential
-	err = in.setCredential(loginResp.Token, ctx)
+	if in.Verify {
+		prompt.Info("Verifying credential...")
+		if !report(provider.Checks(in.Fields)) {
+			prompt.Error("the credential is not valid, nothing was sent")
+			os.Exit(dennis.ExitFailure)
+		}
+	}
+	cred, err := in.credential(provider)
 	if err != nil {
 		prompt.Error(err.Error())
-		os.Exit(1)
+		os.Exit(dennis.ExitFailure)
 	}
 
-}
+	client := dennis.NewClient(host, in.Username, in.Password)
 
-func (in Inputs) login() (loginResponse, error) {
+	// login
 	prompt.Info("Authenticating...")
-
-	loginResp := loginResponse{}
-	loginReq := loginRequest{
-		in.Username,
-		in.Password,
-	}
-	b, err := json.Marshal(&loginReq)
-	if err != nil {
-		return loginResp, fmt.Errorf("error encoding credential: %w", err)
-	}
-
-	loginURL := fmt.Sprintf("%s/login", host)
-	req, err := http.NewRequest(http.MethodPost, loginURL, bytes.NewBuffer(b))
-	if err != nil {
-		return loginResp, fmt.Errorf("error creating request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("x-org", "zup")
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return loginResp, fmt.Errorf("error performing login: %w", err)
-	}
-
-	defer resp.Body.Close()
-
-	b, err = ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return loginResp, fmt.Errorf("error reading response: %w", err)
-	}
-
-	switch resp.StatusCode {
-	case 200:
-		if err = json.Unmarshal(b, &loginResp); err != nil {
-			return loginResp, fmt.Errorf("error decoding response: %w", err)
-		}
-		prompt.Success("done")
-		return loginResp, err
-	case 401:
-		return loginResp, errors.New("login failed! Verify your credentials")
-	default:
-		return loginResp, errors.New("login failed")
+	if err := client.Authenticate(); err != nil {
+		prompt.Error(err.Error())
+		os.Exit(dennis.ExitCode(err))
 	}
+	prompt.Success("done")
 
-}
-
-func (in Inputs) formulas(token string) (formulasResponse, error) {
+	// formulas e context
 	prompt.Info("Obtaining formulas...")
-
-	formulasResp := formulasResponse{}
-
-	formulasURL := fmt.Sprintf("%s/formulas", host)
-	req, err := http.NewRequest(http.MethodGet, formulasURL, nil)
-	if err != nil {
-		return formulasResp, fmt.Errorf("error creating request: %w", err)
-	}
-
-	req.Header.Set("x-org", "zup")
-	req.Header.Set("x-authorization", token)
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return formulasResp, fmt.Errorf("error obtaining formulas: %w", err)
-	}
-
-	defer resp.Body.Close()
-
-	b, err := ioutil.ReadAll(resp.Body)
+	formulasResp, err := client.ListFormulas()
 	if err != nil {
-		return formulasResp, fmt.Errorf("error reading response: %w", err)
+		prompt.Error(err.Error())
+		os.Exit(dennis.ExitCode(err))
 	}
+	prompt.Success("done")
 
-	if resp.StatusCode == 200 {
-		if err = json.Unmarshal(b, &formulasResp); err != nil {
-			return formulasResp, fmt.Errorf("error decoding response: %w", err)
+	if in.Rotate {
+		ok, err := in.rotate(client, formulasResp.Contexts.Names(), cred)
+		if err != nil {
+			prompt.Error(err.Error())
+			os.Exit(dennis.ExitFailure)
 		}
-		prompt.Success("done")
-		return formulasResp, err
-	} else {
-		return formulasResp, errors.New("error obtaining formulas")
-	}
-}
-
-func (in Inputs) setCredential(token, ctx string) error {
-	prompt.Info("Authenticating...")
-
-	type CredentialAWS struct {
-		AccessKeyId     string `json:"accesskeyid"`
-		SecretAccessKey string `json:"secretaccesskey"`
-	}
-
-	type CredentialGithub struct {
-		Username string `json:"username"`
-		Token    string `json:"token"`
+		if !ok {
+			prompt.Error("the credential was not rotated in every context")
+			os.Exit(dennis.ExitFailure)
+		}
+		prompt.Success("Credential rotated in every context")
+		return
 	}
 
-	type credAWSRequest struct {
-		Service    string        `json:"service,omitempty"`
-		Credential CredentialAWS `json:"credential"`
-	}
+	list := prompt.NewSurveyList()
 
-	type credGithubRequest struct {
-		Service    string           `json:"service,omitempty"`
-		Credential CredentialGithub `json:"credential"`
+	ctx, err := list.List("Select a context", formulasResp.Contexts.Names())
+	if err != nil {
+		prompt.Error(err.Error())
+		os.Exit(dennis.ExitFailure)
 	}
 
-	var b []byte
-	var err error
-	if in.Provider == "github" {
-		req := credGithubRequest{
-			Service: in.Provider,
-			Credential: CredentialGithub{
-				Username: in.ProviderUsername,
-				Token:    in.ProviderSecret,
-			},
-		}
-		b, err = json.Marshal(&req)
+	if in.Verify {
+		prompt.Info("Verifying credential with the server...")
+		check, err := verify(client, ctx, cred)
 		if err != nil {
-			return fmt.Errorf("error encoding credential: %w", err)
+			prompt.Error(err.Error())
+			os.Exit(dennis.ExitCode(err))
 		}
-
-	} else {
-		req := credAWSRequest{
-			Service: in.Provider,
-			Credential: CredentialAWS{
-				AccessKeyId:     in.ProviderUsername,
-				SecretAccessKey: in.ProviderSecret,
-			},
-		}
-		b, err = json.Marshal(&req)
-		if err != nil {
-			return fmt.Errorf("error encoding credential: %w", err)
+		if !report([]dennis.Check{check}) {
+			prompt.Error("the credential was refused, nothing was sent")
+			os.Exit(dennis.ExitFailure)
 		}
 	}
 
-	credURL := fmt.Sprintf("%s/credentials", host)
-	req, err := http.NewRequest(http.MethodPost, credURL, bytes.NewBuffer(b))
-	if err != nil {
-		return fmt.Errorf("error creating request: %w", err)
+	if info, ok := currentCredential(client, ctx, cred.Service); ok {
+		prompt.Warning(fmt.Sprintf("Replacing the %s credential of %s, updated at %s", info.Service, ctx, info.UpdatedAt.Format(time.RFC3339)))
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("x-org", "zup")
-	req.Header.Set("x-authorization", token)
-	req.Header.Set("x-ctx", ctx)
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("error setting credential: %w", err)
+	// set credential
+	prompt.Info("Setting credential...")
+	if err = client.SetCredential(ctx, cred); err != nil {
+		prompt.Error(err.Error())
+		os.Exit(dennis.ExitCode(err))
 	}
+	prompt.Success("done")
+}
 
-	defer resp.Body.Close()
-
-	b, err = ioutil.ReadAll(resp.Body)
+// currentCredential returns the credential of the service already set in the context.
+// Servers that do not list credentials are taken as having none.
+func currentCredential(client *dennis.Client, ctx, service string) (dennis.CredentialInfo, bool) {
+	resp, err := client.ListCredentials(ctx)
 	if err != nil {
-		return fmt.Errorf("error reading response: %w", err)
+		return dennis.CredentialInfo{}, false
 	}
-
-	switch resp.StatusCode {
-	case 201:
-		prompt.Success("done")
-		return nil
-	case 401:
-		return errors.New("set credential failed! Verify your credentials")
-	case 403:
-		return errors.New("set credential failed! You have not access for the resource")
-	default:
-		return errors.New(resp.Status + string(b))
+	for _, info := range resp.Credentials {
+		if info.Service == service {
+			return info, true
+		}
 	}
+	return dennis.CredentialInfo{}, false
+}
 
+// credential builds the payload of the provider from the fields, checking
+// them against its declaration when the credential is verified.
+func (in Inputs) credential(provider dennis.Provider) (dennis.CredentialRequest, error) {
+	cred, err := provider.Credential(in.Fields)
+	var invalid dennis.ValidationError
+	if !in.Verify && errors.As(err, &invalid) {
+		return cred, nil
+	}
+	return cred, err
 }
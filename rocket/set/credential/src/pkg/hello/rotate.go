@@ -0,0 +1,128 @@
+package hello
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"dennis"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/ZupIT/ritchie-cli/pkg/prompt"
+)
+
+// errNotSent marks the contexts left untouched because the credential was
+// refused by another one.
+var errNotSent = errors.New("not sent, refused in another context")
+
+// rotation is the outcome of replacing the credential in a context.
+type rotation struct {
+	ctx    string
+	verify string
+	err    error
+}
+
+// rotate replaces the credential in every selected context, retrying the
+// failed contexts for as long as the user asks to. It reports whether every
+// context has the new credential.
+func (in Inputs) rotate(client *dennis.Client, names []string, cred dennis.CredentialRequest) (bool, error) {
+	contexts, err := selectRotation(client, names, cred.Service)
+	if err != nil {
+		return false, err
+	}
+
+	for {
+		results := in.rotateIn(client, contexts, cred)
+		printRotation(results)
+
+		var failed []string
+		for _, r := range results {
+			if r.err != nil {
+				failed = append(failed, r.ctx)
+			}
+		}
+		if len(failed) == 0 {
+			return true, nil
+		}
+
+		label := fmt.Sprintf("Retry the %d failed contexts?", len(failed))
+		retry, err := prompt.NewSurveyBool().Bool(label, []string{"yes", "no"})
+		if err != nil || !retry {
+			return false, err
+		}
+		contexts = failed
+	}
+}
+
+// selectRotation prompts for the contexts to rotate the credential in, the
+// ones where it is already set selected by default.
+func selectRotation(client *dennis.Client, names []string, service string) ([]string, error) {
+	var current []string
+	for _, ctx := range names {
+		if _, ok := currentCredential(client, ctx, service); ok {
+			current = append(current, ctx)
+		}
+	}
+
+	var contexts []string
+	err := survey.AskOne(&survey.MultiSelect{
+		Message: "Select the contexts to rotate the credential in",
+		Options: names,
+		Default: current,
+	}, &contexts, survey.WithValidator(survey.Required))
+	return contexts, err
+}
+
+// rotateIn verifies the credential in every context first and uploads it only
+// when no context refuses it, so a bad credential replaces none.
+func (in Inputs) rotateIn(client *dennis.Client, contexts []string, cred dennis.CredentialRequest) []rotation {
+	results := make([]rotation, len(contexts))
+	refused := false
+	for i, ctx := range contexts {
+		results[i] = rotation{ctx: ctx, verify: "off"}
+		if !in.Verify {
+			continue
+		}
+
+		prompt.Info(fmt.Sprintf("Verifying credential in %s...", ctx))
+		check, err := verify(client, ctx, cred)
+		switch {
+		case err != nil:
+			results[i].verify, results[i].err = "failed", err
+		case check.Skipped:
+			results[i].verify = "skipped"
+		case !check.Passed():
+			results[i].verify, results[i].err = "failed", errors.New(check.Problems[0])
+		default:
+			results[i].verify = "ok"
+		}
+		refused = refused || results[i].err != nil
+	}
+
+	for i, r := range results {
+		if refused {
+			if r.err == nil {
+				results[i].err = errNotSent
+			}
+			continue
+		}
+
+		prompt.Info(fmt.Sprintf("Setting credential in %s...", r.ctx))
+		results[i].err = client.SetCredential(r.ctx, cred)
+	}
+	return results
+}
+
+func printRotation(results []rotation) {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CONTEXT\tVERIFY\tRESULT")
+	for _, r := range results {
+		result := "rotated"
+		if r.err != nil {
+			result = "failed: " + r.err.Error()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", r.ctx, r.verify, result)
+	}
+	_ = tw.Flush()
+}
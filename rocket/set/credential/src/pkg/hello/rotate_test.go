@@ -0,0 +1,66 @@
+package hello
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"dennis"
+)
+
+func TestInputs_rotateIn(t *testing.T) {
+	var mu sync.Mutex
+	var sets []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Header.Get("x-ctx")
+		switch r.URL.Path {
+		case "/credentials/verify":
+			if ctx == "QA" {
+				_, _ = w.Write([]byte(`{"valid": false, "message": "expired token"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"valid": true}`))
+		case "/credentials":
+			mu.Lock()
+			sets = append(sets, ctx)
+			mu.Unlock()
+			if ctx == "PROD" {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer srv.Close()
+	client := dennis.NewClient(srv.URL, "user", "pass")
+	client.Tokens = dennis.TokenStore{}
+	cred := dennis.CredentialRequest{Service: "github", Credential: map[string]string{"token": "t"}}
+
+	tests := []struct {
+		name      string
+		verify    bool
+		contexts  []string
+		wantSets  []string
+		wantFails []bool
+	}{
+		{name: "rotated", verify: true, contexts: []string{"DEV"}, wantSets: []string{"DEV"}, wantFails: []bool{false}},
+		{name: "refused in a context", verify: true, contexts: []string{"DEV", "QA"}, wantFails: []bool{true, true}},
+		{name: "failed upload", verify: true, contexts: []string{"DEV", "PROD"}, wantSets: []string{"DEV", "PROD"}, wantFails: []bool{false, true}},
+		{name: "without verification", contexts: []string{"QA"}, wantSets: []string{"QA"}, wantFails: []bool{false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sets = nil
+			results := Inputs{Verify: tt.verify}.rotateIn(client, tt.contexts, cred)
+			if len(sets) != len(tt.wantSets) {
+				t.Fatalf("rotateIn() set the credential in %v, want %v", sets, tt.wantSets)
+			}
+			for i, r := range results {
+				if (r.err != nil) != tt.wantFails[i] {
+					t.Errorf("rotateIn() %s error = %v, want failed %v", r.ctx, r.err, tt.wantFails[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package hello
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"dennis"
+)
+
+// verify asks the server to verify the credential in the context. A server
+// without the verify endpoint skips the check.
+func verify(client *dennis.Client, ctx string, cred dennis.CredentialRequest) (dennis.Check, error) {
+	check := dennis.Check{Name: "server"}
+	resp, err := client.VerifyCredential(ctx, cred)
+	switch {
+	case errors.Is(err, dennis.ErrVerifyUnsupported):
+		check.Skipped = true
+		check.Problems = []string{err.Error()}
+	case err != nil:
+		return check, err
+	case !resp.Valid:
+		check.Problems = []string{resp.Message}
+	}
+	return check, nil
+}
+
+// report prints a line per check, with the problems of the failed ones and
+// the reason of the skipped ones, and tells whether none failed.
+func report(checks []dennis.Check) bool {
+	ok := true
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, c := range checks {
+		switch {
+		case c.Skipped:
+			fmt.Fprintf(tw, "  %s\tskipped\t%s\n", c.Name, strings.Join(c.Problems, "; "))
+		case c.Passed():
+			fmt.Fprintf(tw, "  %s\tok\n", c.Name)
+		default:
+			ok = false
+			fmt.Fprintf(tw, "  %s\tfailed\t%s\n", c.Name, strings.Join(c.Problems, "; "))
+		}
+	}
+	_ = tw.Flush()
+	return ok
+}
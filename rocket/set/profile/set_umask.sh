@@ -0,0 +1,3 @@
+#!/bin/sh
+umask 0011
+$1
@@ -0,0 +1,377 @@
+// Package dennis is the client of the Dennis remote execution API consumed by
+// the rocket formulas.
+package dennis
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+const (
+	org = "zup"
+
+	StatusReady    = "Ready"
+	StatusCanceled = "Canceled"
+)
+
+type Client struct {
+	Host     string
+	Username string
+	Password string
+	HTTP     *http.Client
+	Tokens   TokenStore
+
+	mu    sync.Mutex
+	token string
+}
+
+type response struct {
+	status int
+	body   []byte
+}
+
+func NewClient(host, username, password string) *Client {
+	return &Client{
+		Host:     host,
+		Username: username,
+		Password: password,
+		HTTP:     http.DefaultClient,
+		Tokens:   DefaultTokenStore(),
+	}
+}
+
+// Token returns the token obtained by the last successful Login.
+func (c *Client) Token() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.token
+}
+
+func (c *Client) setToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+}
+
+// Authenticate reuses the token cached for the client user and endpoint,
+// logging in only when there is no valid one.
+func (c *Client) Authenticate() error {
+	if token, ok := c.Tokens.Get(c.Host, c.Username); ok {
+		c.setToken(token)
+		return nil
+	}
+	_, err := c.Login()
+	return err
+}
+
+// Login always performs a new login and caches the obtained token.
+func (c *Client) Login() (LoginResponse, error) {
+	loginResp := LoginResponse{}
+	b, err := json.Marshal(&LoginRequest{
+		Username: c.Username,
+		Password: c.Password,
+	})
+	if err != nil {
+		return loginResp, fmt.Errorf("error encoding credential: %w", err)
+	}
+
+	resp, err := c.do(context.Background(), http.MethodPost, "/login", "", b)
+	if err != nil {
+		return loginResp, fmt.Errorf("error performing login: %w", err)
+	}
+
+	switch resp.status {
+	case 200:
+		if err = json.Unmarshal(resp.body, &loginResp); err != nil {
+			return loginResp, fmt.Errorf("error decoding response: %w", err)
+		}
+		c.setToken(loginResp.Token)
+		_ = c.Tokens.Put(c.Host, c.Username, loginResp)
+		return loginResp, nil
+	case 401:
+		return loginResp, statusError(resp.status, "login failed! Verify your credentials")
+	default:
+		return loginResp, statusError(resp.status, "login failed")
+	}
+}
+
+func (c *Client) ListFormulas() (FormulasResponse, error) {
+	formulasResp := FormulasResponse{}
+
+	resp, err := c.do(context.Background(), http.MethodGet, "/formulas", "", nil)
+	if err != nil {
+		return formulasResp, fmt.Errorf("error obtaining formulas: %w", err)
+	}
+
+	if resp.status != 200 {
+		return formulasResp, statusError(resp.status, "error obtaining formulas")
+	}
+
+	if err = json.Unmarshal(resp.body, &formulasResp); err != nil {
+		return formulasResp, fmt.Errorf("error decoding response: %w", err)
+	}
+	return formulasResp, nil
+}
+
+// SendCommand posts the command to the context. Canceling goCtx aborts the
+// request, so nothing is submitted after an interruption.
+func (c *Client) SendCommand(goCtx context.Context, ctx string, cmd CommandRequest) error {
+	b, err := json.Marshal(&cmd)
+	if err != nil {
+		return fmt.Errorf("error encoding command: %w", err)
+	}
+
+	resp, err := c.do(goCtx, http.MethodPost, "/commands", ctx, b)
+	if err != nil {
+		return fmt.Errorf("error sending command: %w", err)
+	}
+
+	switch resp.status {
+	case 201:
+		return nil
+	case 401, 403:
+		return statusError(resp.status, "authorization failed! Verify your credentials")
+	default:
+		return statusError(resp.status, "command failed")
+	}
+}
+
+// GetExecution returns the execution with the given ID. An execution that is
+// not found may still be queued, see IsNotFound.
+func (c *Client) GetExecution(ctx, id string) (ExecutionResponse, error) {
+	return c.getExecution(context.Background(), ctx, id)
+}
+
+func (c *Client) getExecution(goCtx context.Context, ctx, id string) (ExecutionResponse, error) {
+	execResp := ExecutionResponse{}
+
+	resp, err := c.do(goCtx, http.MethodGet, "/executions/"+id, ctx, nil)
+	if err != nil {
+		return execResp, fmt.Errorf("error getting execution: %w", err)
+	}
+
+	switch resp.status {
+	case 200:
+		if err = json.Unmarshal(resp.body, &execResp); err != nil {
+			return execResp, fmt.Errorf("error decoding response: %w", err)
+		}
+		return execResp, nil
+	case 401, 403:
+		return execResp, statusError(resp.status, "authorization failed! Verify your credentials")
+	case 404:
+		return execResp, statusError(resp.status, "execution not found")
+	default:
+		return execResp, statusError(resp.status, "error getting execution")
+	}
+}
+
+// CancelExecution asks Dennis to abort the execution. A conflict, see
+// IsConflict, means the execution has already finished.
+func (c *Client) CancelExecution(ctx, id string) error {
+	resp, err := c.do(context.Background(), http.MethodPost, "/executions/"+id+"/cancel", ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error canceling execution: %w", err)
+	}
+
+	switch resp.status {
+	case 200, 202, 204:
+		return nil
+	case 401, 403:
+		return statusError(resp.status, "authorization failed! Verify your credentials")
+	case 404:
+		return statusError(resp.status, "execution not found")
+	case 409:
+		return statusError(resp.status, "execution already finished")
+	case 405, 501:
+		return statusError(resp.status, "canceling executions is not supported by the server")
+	default:
+		return statusError(resp.status, "error canceling execution")
+	}
+}
+
+// ListExecutions returns a page of the executions run in the context which
+// are selected by the filter, sorted by start time.
+func (c *Client) ListExecutions(ctx string, filter ExecutionFilter) (ExecutionsResponse, error) {
+	execsResp := ExecutionsResponse{}
+
+	resp, err := c.do(context.Background(), http.MethodGet, "/executions?"+filter.Query().Encode(), ctx, nil)
+	if err != nil {
+		return execsResp, fmt.Errorf("error listing executions: %w", err)
+	}
+
+	switch resp.status {
+	case 200:
+		if err = json.Unmarshal(resp.body, &execsResp); err != nil {
+			return execsResp, fmt.Errorf("error decoding response: %w", err)
+		}
+		execsResp.Executions = filter.Apply(execsResp.Executions)
+		return execsResp, nil
+	case 401, 403:
+		return execsResp, statusError(resp.status, "authorization failed! Verify your credentials")
+	default:
+		return execsResp, statusError(resp.status, "error listing executions")
+	}
+}
+
+func (c *Client) SetCredential(ctx string, cred CredentialRequest) error {
+	b, err := json.Marshal(&cred)
+	if err != nil {
+		return fmt.Errorf("error encoding credential: %w", err)
+	}
+
+	resp, err := c.do(context.Background(), http.MethodPost, "/credentials", ctx, b)
+	if err != nil {
+		return fmt.Errorf("error setting credential: %w", err)
+	}
+
+	switch resp.status {
+	case 201:
+		return nil
+	case 401:
+		return statusError(resp.status, "set credential failed! Verify your credentials")
+	case 403:
+		return statusError(resp.status, "set credential failed! You have not access for the resource")
+	default:
+		return statusError(resp.status, fmt.Sprintf("set credential failed: %d %s", resp.status, resp.body))
+	}
+}
+
+// VerifyCredential asks Dennis whether the credential works with its
+// provider, without storing it. ErrVerifyUnsupported is returned when the
+// server has no verify endpoint.
+func (c *Client) VerifyCredential(ctx string, cred CredentialRequest) (VerifyResponse, error) {
+	verifyResp := VerifyResponse{}
+	b, err := json.Marshal(&cred)
+	if err != nil {
+		return verifyResp, fmt.Errorf("error encoding credential: %w", err)
+	}
+
+	resp, err := c.do(context.Background(), http.MethodPost, "/credentials/verify", ctx, b)
+	if err != nil {
+		return verifyResp, fmt.Errorf("error verifying credential: %w", err)
+	}
+
+	switch resp.status {
+	case 200, 400, 422:
+		if err = json.Unmarshal(resp.body, &verifyResp); err != nil && resp.status == 200 {
+			return verifyResp, fmt.Errorf("error decoding response: %w", err)
+		}
+		verifyResp.Valid = verifyResp.Valid && resp.status == 200
+		if !verifyResp.Valid && verifyResp.Message == "" {
+			verifyResp.Message = fmt.Sprintf("refused by the server with status %d", resp.status)
+		}
+		return verifyResp, nil
+	case 401, 403:
+		return verifyResp, statusError(resp.status, "authorization failed! Verify your credentials")
+	case 404, 405, 501:
+		return verifyResp, ErrVerifyUnsupported
+	default:
+		return verifyResp, statusError(resp.status, "error verifying credential")
+	}
+}
+
+// ListCredentials returns the credentials set in the context, without their
+// secret values.
+func (c *Client) ListCredentials(ctx string) (CredentialsResponse, error) {
+	credsResp := CredentialsResponse{}
+
+	resp, err := c.do(context.Background(), http.MethodGet, "/credentials", ctx, nil)
+	if err != nil {
+		return credsResp, fmt.Errorf("error listing credentials: %w", err)
+	}
+
+	switch resp.status {
+	case 200:
+		if err = json.Unmarshal(resp.body, &credsResp); err != nil {
+			return credsResp, fmt.Errorf("error decoding response: %w", err)
+		}
+		return credsResp, nil
+	case 401, 403:
+		return credsResp, statusError(resp.status, "authorization failed! Verify your credentials")
+	case 405, 501:
+		return credsResp, statusError(resp.status, "listing credentials is not supported by the server")
+	default:
+		return credsResp, statusError(resp.status, "error listing credentials")
+	}
+}
+
+// DeleteCredential removes the credential of the provider from the context.
+func (c *Client) DeleteCredential(ctx, provider string) error {
+	resp, err := c.do(context.Background(), http.MethodDelete, "/credentials/"+url.PathEscape(provider), ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting credential: %w", err)
+	}
+
+	switch resp.status {
+	case 200, 202, 204:
+		return nil
+	case 401, 403:
+		return statusError(resp.status, "authorization failed! Verify your credentials")
+	case 404:
+		return statusError(resp.status, fmt.Sprintf("credential %s not found", provider))
+	case 405, 501:
+		return statusError(resp.status, "deleting credentials is not supported by the server")
+	default:
+		return statusError(resp.status, "error deleting credential")
+	}
+}
+
+// do performs a request against the Dennis API. A 401 on a request made with
+// a token, which may have been revoked before its ttl, triggers a new login
+// and a single retry.
+func (c *Client) do(goCtx context.Context, method, path, ctx string, body []byte) (response, error) {
+	resp, err := c.send(goCtx, method, path, ctx, body)
+	if err != nil || resp.status != 401 || path == "/login" || c.Token() == "" {
+		return resp, err
+	}
+
+	_ = c.Tokens.Delete(c.Host, c.Username)
+	if _, err = c.Login(); err != nil {
+		return resp, err
+	}
+	return c.send(goCtx, method, path, ctx, body)
+}
+
+// send performs a request against the Dennis API, setting the org, token and
+// context headers, and returns the status code and the read body.
+func (c *Client) send(goCtx context.Context, method, path, ctx string, body []byte) (response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewBuffer(body)
+	}
+
+	req, err := http.NewRequestWithContext(goCtx, method, c.Host+path, reader)
+	if err != nil {
+		return response{}, fmt.Errorf("error creating request: %w", err)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("x-org", org)
+	if token := c.Token(); token != "" {
+		req.Header.Set("x-authorization", token)
+	}
+	if ctx != "" {
+		req.Header.Set("x-ctx", ctx)
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return response{}, err
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return response{}, fmt.Errorf("error reading response: %w", err)
+	}
+	return response{status: resp.StatusCode, body: b}, nil
+}
@@ -0,0 +1,145 @@
+package dennis
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	HostEnv        = "DENNIS_HOST"
+	ProfileEnv     = "DENNIS_PROFILE"
+	DefaultProfile = "dev"
+)
+
+// DefaultProfiles are always available and can not be overwritten by the
+// endpoint config file.
+var DefaultProfiles = map[string]string{
+	"dev":   "https://dennis.devdennis.zup.io",
+	"local": "http://localhost:8882",
+}
+
+type EndpointConfig struct {
+	Current  string            `json:"current,omitempty"`
+	Profiles map[string]string `json:"profiles,omitempty"`
+}
+
+// Active returns the name of the active profile.
+func (cfg EndpointConfig) Active() string {
+	if cfg.Current == "" {
+		return DefaultProfile
+	}
+	return cfg.Current
+}
+
+// ResolveHost returns the Dennis endpoint used by every request. The
+// DENNIS_HOST env var wins, then the profile named by DENNIS_PROFILE and
+// finally the active profile of ~/.rit/rocket/endpoint.json.
+func ResolveHost() (string, error) {
+	if h := os.Getenv(HostEnv); h != "" {
+		return strings.TrimSuffix(h, "/"), nil
+	}
+
+	cfg, err := LoadEndpointConfig()
+	if err != nil {
+		return "", err
+	}
+
+	name := os.Getenv(ProfileEnv)
+	if name == "" {
+		name = cfg.Active()
+	}
+
+	h, ok := cfg.Profiles[name]
+	if !ok {
+		return "", fmt.Errorf("endpoint profile %q not found, use [rit rocket set profile]", name)
+	}
+	return strings.TrimSuffix(h, "/"), nil
+}
+
+// LoadEndpointConfig reads the endpoint config file merged with the
+// DefaultProfiles. A missing file is not an error.
+func LoadEndpointConfig() (EndpointConfig, error) {
+	cfg := EndpointConfig{Profiles: map[string]string{}}
+	for name, h := range DefaultProfiles {
+		cfg.Profiles[name] = h
+	}
+
+	path, err := endpointFile()
+	if err != nil {
+		return cfg, err
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("error reading endpoint config: %w", err)
+	}
+
+	file := EndpointConfig{}
+	if err = json.Unmarshal(b, &file); err != nil {
+		return cfg, fmt.Errorf("error decoding endpoint config: %w", err)
+	}
+
+	cfg.Current = file.Current
+	for name, h := range file.Profiles {
+		if _, ok := DefaultProfiles[name]; !ok {
+			cfg.Profiles[name] = h
+		}
+	}
+	return cfg, nil
+}
+
+// SaveEndpointConfig persists the active profile and the custom profiles
+// only, so the built-in ones can be changed by a new version of the formulas.
+func SaveEndpointConfig(cfg EndpointConfig) error {
+	file := EndpointConfig{
+		Current:  cfg.Current,
+		Profiles: map[string]string{},
+	}
+	for name, h := range cfg.Profiles {
+		if _, ok := DefaultProfiles[name]; !ok {
+			file.Profiles[name] = h
+		}
+	}
+
+	b, err := json.MarshalIndent(&file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding endpoint config: %w", err)
+	}
+
+	path, err := endpointFile()
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating config dir: %w", err)
+	}
+	if err = ioutil.WriteFile(path, b, 0644); err != nil {
+		return fmt.Errorf("error writing endpoint config: %w", err)
+	}
+	return nil
+}
+
+func endpointFile() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "endpoint.json"), nil
+}
+
+// Dir returns the directory where the rocket formulas keep their local state,
+// ~/.rit/rocket.
+func Dir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("error finding home dir: %w", err)
+	}
+	return filepath.Join(home, ".rit", "rocket"), nil
+}
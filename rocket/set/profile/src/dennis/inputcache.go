@@ -0,0 +1,104 @@
+package dennis
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// DefaultCacheQty is how many values of an input are kept when its cache
+	// does not set it.
+	DefaultCacheQty = 5
+	// DefaultCacheNewLabel is the option to type a new value when the cache
+	// of an input does not set it.
+	DefaultCacheNewLabel = "Type new value?"
+)
+
+// InputCache persists the recent values of cached inputs per formula command
+// in a JSON file. A cache with an empty Path keeps nothing.
+type InputCache struct {
+	Path string
+}
+
+// DefaultInputCache returns the cache kept in ~/.rit/rocket/inputs.json.
+func DefaultInputCache() InputCache {
+	dir, err := Dir()
+	if err != nil {
+		return InputCache{}
+	}
+	return InputCache{Path: filepath.Join(dir, "inputs.json")}
+}
+
+// Get returns the recent values of the input of the command, newest first.
+func (c InputCache) Get(command, input string) []string {
+	values, err := c.load()
+	if err != nil {
+		return nil
+	}
+	return values[command][input]
+}
+
+// Put records a value of the input of the command, keeping the newest qty
+// values.
+func (c InputCache) Put(command, input, value string, qty int) error {
+	if c.Path == "" || value == "" {
+		return nil
+	}
+	if qty <= 0 {
+		qty = DefaultCacheQty
+	}
+
+	values, err := c.load()
+	if err != nil {
+		values = map[string]map[string][]string{}
+	}
+	if values[command] == nil {
+		values[command] = map[string][]string{}
+	}
+
+	recent := []string{value}
+	for _, v := range values[command][input] {
+		if v != value && len(recent) < qty {
+			recent = append(recent, v)
+		}
+	}
+	values[command][input] = recent
+	return c.save(values)
+}
+
+func (c InputCache) load() (map[string]map[string][]string, error) {
+	values := map[string]map[string][]string{}
+	if c.Path == "" {
+		return values, nil
+	}
+
+	b, err := ioutil.ReadFile(c.Path)
+	if os.IsNotExist(err) {
+		return values, nil
+	}
+	if err != nil {
+		return values, fmt.Errorf("error reading input cache: %w", err)
+	}
+
+	if err = json.Unmarshal(b, &values); err != nil {
+		return values, fmt.Errorf("error decoding input cache: %w", err)
+	}
+	return values, nil
+}
+
+func (c InputCache) save(values map[string]map[string][]string) error {
+	b, err := json.Marshal(&values)
+	if err != nil {
+		return fmt.Errorf("error encoding input cache: %w", err)
+	}
+	if err = os.MkdirAll(filepath.Dir(c.Path), 0700); err != nil {
+		return fmt.Errorf("error creating input cache dir: %w", err)
+	}
+	if err = ioutil.WriteFile(c.Path, b, 0600); err != nil {
+		return fmt.Errorf("error writing input cache: %w", err)
+	}
+	return nil
+}
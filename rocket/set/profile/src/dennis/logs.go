@@ -0,0 +1,132 @@
+package dennis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+)
+
+// Streams of a log chunk.
+const (
+	StreamStdout = "stdout"
+	StreamStderr = "stderr"
+)
+
+// ErrLogsUnsupported is returned when the server has no execution logs
+// endpoint, so the output is only known once the execution is ready.
+var ErrLogsUnsupported = errors.New("execution logs are not supported by the server")
+
+// LogChunk is a piece of the output of a remote formula.
+type LogChunk struct {
+	Stream string `json:"stream"`
+	Data   string `json:"data"`
+}
+
+// LogsResponse holds the chunks written after the requested offset and the
+// offset to request next.
+type LogsResponse struct {
+	Offset int64      `json:"offset"`
+	Chunks []LogChunk `json:"chunks"`
+	Done   bool       `json:"done,omitempty"`
+}
+
+// GetLogs returns the output of the execution written after offset, from
+// GET /executions/{id}/logs?offset=.
+func (c *Client) GetLogs(goCtx context.Context, ctx, id string, offset int64) (LogsResponse, error) {
+	logsResp := LogsResponse{}
+
+	path := "/executions/" + id + "/logs?offset=" + strconv.FormatInt(offset, 10)
+	resp, err := c.do(goCtx, http.MethodGet, path, ctx, nil)
+	if err != nil {
+		return logsResp, fmt.Errorf("error getting execution logs: %w", err)
+	}
+
+	switch resp.status {
+	case 200:
+		if err = json.Unmarshal(resp.body, &logsResp); err != nil {
+			return logsResp, fmt.Errorf("%w: %s", ErrLogsUnsupported, err)
+		}
+		return logsResp, nil
+	case 401, 403:
+		return logsResp, statusError(resp.status, "authorization failed! Verify your credentials")
+	case 404:
+		return logsResp, statusError(resp.status, "execution logs not found")
+	case 405, 501:
+		return logsResp, ErrLogsUnsupported
+	default:
+		return logsResp, statusError(resp.status, "error getting execution logs")
+	}
+}
+
+// LogTail reads the output of an execution incrementally, keeping the offset
+// between calls. Polled, it turns itself off when the server does not support
+// logs, leaving the output to be printed with the result.
+type LogTail struct {
+	Client  *Client
+	Context string
+	ID      string
+	Offset  int64
+
+	// Stdout and Stderr receive the printed chunks, os.Stdout and os.Stderr
+	// when nil.
+	Stdout io.Writer
+	Stderr io.Writer
+
+	off      bool
+	streamed bool
+}
+
+// Next returns the chunks written since the previous call.
+func (t *LogTail) Next(goCtx context.Context) ([]LogChunk, error) {
+	resp, err := t.Client.GetLogs(goCtx, t.Context, t.ID, t.Offset)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Offset > t.Offset {
+		t.Offset = resp.Offset
+	}
+	return resp.Chunks, nil
+}
+
+// Poll returns the chunks written since the previous poll, ignoring errors.
+// execErr is the error of the execution poll, telling a queued execution,
+// whose logs are not found either, from a server without logs.
+func (t *LogTail) Poll(goCtx context.Context, execErr error) []LogChunk {
+	if t.off {
+		return nil
+	}
+
+	chunks, err := t.Next(goCtx)
+	if errors.Is(err, ErrLogsUnsupported) || IsNotFound(err) && execErr == nil {
+		t.off = true
+	}
+	return chunks
+}
+
+// Print writes stdout chunks to stdout and stderr chunks to stderr.
+func (t *LogTail) Print(chunks []LogChunk) {
+	for _, chunk := range chunks {
+		var w io.Writer = os.Stdout
+		if t.Stdout != nil {
+			w = t.Stdout
+		}
+		if chunk.Stream == StreamStderr {
+			w = os.Stderr
+			if t.Stderr != nil {
+				w = t.Stderr
+			}
+		}
+		fmt.Fprint(w, chunk.Data)
+		t.streamed = true
+	}
+}
+
+// Streamed reports whether any output was printed.
+func (t *LogTail) Streamed() bool {
+	return t.streamed
+}
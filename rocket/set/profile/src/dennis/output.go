@@ -0,0 +1,179 @@
+package dennis
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gookit/color"
+	"gopkg.in/yaml.v2"
+)
+
+// Output formats of an execution. OutputText is the colored report printed by
+// the formulas themselves.
+const (
+	OutputText  = "text"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+	OutputTable = "table"
+)
+
+// ExecutionOutput is the machine readable form of an execution. Error holds
+// why an execution could not be submitted or awaited, e.g. in a batch.
+type ExecutionOutput struct {
+	ID         string            `json:"id" yaml:"id"`
+	Context    string            `json:"context,omitempty" yaml:"context,omitempty"`
+	Command    string            `json:"command,omitempty" yaml:"command,omitempty"`
+	Status     string            `json:"status" yaml:"status"`
+	User       string            `json:"user,omitempty" yaml:"user,omitempty"`
+	StartTime  string            `json:"startTime,omitempty" yaml:"startTime,omitempty"`
+	EndTime    string            `json:"endTime,omitempty" yaml:"endTime,omitempty"`
+	Duration   string            `json:"duration,omitempty" yaml:"duration,omitempty"`
+	Inputs     map[string]string `json:"inputs,omitempty" yaml:"inputs,omitempty"`
+	Stdout     string            `json:"stdout" yaml:"stdout"`
+	Stderr     string            `json:"stderr" yaml:"stderr"`
+	StatusCode int               `json:"statusCode" yaml:"statusCode"`
+	Error      string            `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// CheckOutput validates an output format.
+func CheckOutput(format string) error {
+	switch format {
+	case OutputText, OutputJSON, OutputYAML, OutputTable:
+		return nil
+	default:
+		return fmt.Errorf("invalid output %q, use text, json, yaml or table", format)
+	}
+}
+
+func NewExecutionOutput(ctx string, resp ExecutionResponse) ExecutionOutput {
+	cont := resp.Content
+	out := ExecutionOutput{
+		ID:         cont.ID,
+		Context:    ctx,
+		Command:    cont.Command,
+		Status:     resp.Status,
+		User:       cont.User,
+		Stdout:     cont.FormulaOut,
+		Stderr:     cont.FormulaErr,
+		StatusCode: cont.StatusCode,
+	}
+
+	if cont.StartTime.Unix() > 0 {
+		out.StartTime = cont.StartTime.Time().Format(time.RFC3339)
+	}
+	if cont.EndTime.Unix() > 0 {
+		out.EndTime = cont.EndTime.Time().Format(time.RFC3339)
+		out.Duration = cont.Duration().String()
+	}
+
+	if len(cont.FormulaInputs) > 0 {
+		out.Inputs = map[string]string{}
+		for _, input := range cont.FormulaInputs {
+			out.Inputs[input.Name] = input.Value
+		}
+	}
+	return out
+}
+
+// WriteExecution writes the execution as json, yaml or a plain table.
+func WriteExecution(w io.Writer, format string, out ExecutionOutput) error {
+	switch format {
+	case OutputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(&out)
+	case OutputYAML:
+		b, err := yaml.Marshal(&out)
+		if err != nil {
+			return fmt.Errorf("error encoding execution: %w", err)
+		}
+		_, err = w.Write(b)
+		return err
+	case OutputTable:
+		return writeTable(w, out)
+	default:
+		return CheckOutput(format)
+	}
+}
+
+// WriteExecutions writes a list of executions as json, yaml or a table with a
+// row per execution. Stdout, stderr and inputs are left out of the table.
+func WriteExecutions(w io.Writer, format string, outs []ExecutionOutput) error {
+	switch format {
+	case OutputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(outs)
+	case OutputYAML:
+		b, err := yaml.Marshal(outs)
+		if err != nil {
+			return fmt.Errorf("error encoding executions: %w", err)
+		}
+		_, err = w.Write(b)
+		return err
+	case OutputText, OutputTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "CONTEXT\tEXECUTION ID\tCOMMAND\tSTATUS\tUSER\tSTART TIME\tDURATION\tCODE")
+		for _, out := range outs {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\n",
+				out.Context, out.ID, out.Command, out.Status, out.User, out.StartTime, out.Duration, out.StatusCode)
+		}
+		return tw.Flush()
+	default:
+		return CheckOutput(format)
+	}
+}
+
+// RedirectOutput sends the progress messages to stderr when the result is
+// machine readable, so stdout only carries the result. It returns where the
+// result is written.
+func RedirectOutput(format string) io.Writer {
+	stdout := os.Stdout
+	if format != OutputText {
+		os.Stdout = os.Stderr
+		color.SetOutput(os.Stderr)
+	}
+	return stdout
+}
+
+func writeTable(w io.Writer, out ExecutionOutput) error {
+	names := make([]string, 0, len(out.Inputs))
+	for name := range out.Inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	inputs := make([]string, len(names))
+	for i, name := range names {
+		inputs[i] = name + "=" + out.Inputs[name]
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	rows := [][2]string{
+		{"ID", out.ID},
+		{"CONTEXT", out.Context},
+		{"COMMAND", out.Command},
+		{"STATUS", out.Status},
+		{"USER", out.User},
+		{"START TIME", out.StartTime},
+		{"END TIME", out.EndTime},
+		{"DURATION", out.Duration},
+		{"STATUS CODE", fmt.Sprint(out.StatusCode)},
+		{"INPUTS", strings.Join(inputs, ", ")},
+		{"STDOUT", out.Stdout},
+		{"STDERR", out.Stderr},
+	}
+	for _, row := range rows {
+		lines := strings.Split(strings.TrimRight(row[1], "\n"), "\n")
+		fmt.Fprintf(tw, "%s\t%s\n", row[0], lines[0])
+		for _, line := range lines[1:] {
+			fmt.Fprintf(tw, "\t%s\n", line)
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,10 @@
+module rocket/profile
+
+go 1.14
+
+require (
+	dennis v0.0.0-00010101000000-000000000000
+	github.com/ZupIT/ritchie-cli v0.0.0-20200806162951-cd8acdae49af
+)
+
+replace dennis => ./dennis
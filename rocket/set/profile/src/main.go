@@ -0,0 +1,16 @@
+package main
+
+import (
+	"dennis"
+	"os"
+	"rocket/profile/pkg/profile"
+
+	"github.com/ZupIT/ritchie-cli/pkg/prompt"
+)
+
+func main() {
+	if err := (profile.Inputs{}).Run(); err != nil {
+		prompt.Error(err.Error())
+		os.Exit(dennis.ExitCode(err))
+	}
+}
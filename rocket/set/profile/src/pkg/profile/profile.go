@@ -0,0 +1,147 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"dennis"
+
+	"github.com/ZupIT/ritchie-cli/pkg/prompt"
+)
+
+const (
+	newProfile    = "Add a new profile"
+	removeProfile = "Remove a profile"
+)
+
+var errBuiltIn = errors.New("built-in profiles can not be changed")
+
+type Inputs struct{}
+
+func (in Inputs) Run() error {
+	cfg, err := dennis.LoadEndpointConfig()
+	if err != nil {
+		return err
+	}
+
+	current := cfg.Active()
+	names := profileNames(cfg, false)
+
+	fmt.Println("Profiles:")
+	for _, name := range names {
+		if name == current {
+			prompt.Success(fmt.Sprintf("* %s\t%s", name, cfg.Profiles[name]))
+		} else {
+			fmt.Printf("  %s\t%s\n", name, cfg.Profiles[name])
+		}
+	}
+	fmt.Println()
+
+	list := prompt.NewSurveyList()
+	items := append(names, newProfile)
+	if custom := profileNames(cfg, true); len(custom) > 0 {
+		items = append(items, removeProfile)
+	}
+	name, err := list.List("Select the active profile", items)
+	if err != nil {
+		return err
+	}
+
+	switch name {
+	case newProfile:
+		if name, err = readProfile(&cfg); err != nil {
+			return err
+		}
+	case removeProfile:
+		name, err = list.List("Select the profile to remove", profileNames(cfg, true))
+		if err != nil {
+			return err
+		}
+		if err = remove(&cfg, name); err != nil {
+			return err
+		}
+		if err = dennis.SaveEndpointConfig(cfg); err != nil {
+			return err
+		}
+		prompt.Success(fmt.Sprintf("Profile %s removed, active profile: %s", name, cfg.Active()))
+		return nil
+	}
+
+	if err = switchTo(&cfg, name); err != nil {
+		return err
+	}
+	if err = dennis.SaveEndpointConfig(cfg); err != nil {
+		return err
+	}
+
+	prompt.Success(fmt.Sprintf("Active profile: %s (%s)", name, cfg.Profiles[name]))
+	return nil
+}
+
+// readProfile prompts for a new profile and adds it, returning its name.
+func readProfile(cfg *dennis.EndpointConfig) (string, error) {
+	name, err := prompt.NewSurveyText().Text("Profile name [e.g stg, prod]: ", true)
+	if err != nil {
+		return "", fmt.Errorf("error reading profile: %w", err)
+	}
+	host, err := prompt.NewSurveyURL().URL("Dennis URL: ", "https://")
+	if err != nil {
+		return "", fmt.Errorf("error reading profile: %w", err)
+	}
+
+	name = strings.TrimSpace(name)
+	return name, add(cfg, name, host)
+}
+
+// add adds the profile, or replaces a custom one with the same name.
+func add(cfg *dennis.EndpointConfig, name, host string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("the profile name can not be empty")
+	}
+	if _, ok := dennis.DefaultProfiles[name]; ok {
+		return errBuiltIn
+	}
+	cfg.Profiles[name] = strings.TrimSuffix(host, "/")
+	return nil
+}
+
+// switchTo makes the profile the active one.
+func switchTo(cfg *dennis.EndpointConfig, name string) error {
+	if _, ok := cfg.Profiles[name]; !ok {
+		return fmt.Errorf("endpoint profile %q not found", name)
+	}
+	cfg.Current = name
+	return nil
+}
+
+// remove removes a custom profile. When it was the active one, the default
+// profile becomes active.
+func remove(cfg *dennis.EndpointConfig, name string) error {
+	if _, ok := dennis.DefaultProfiles[name]; ok {
+		return errBuiltIn
+	}
+	if _, ok := cfg.Profiles[name]; !ok {
+		return fmt.Errorf("endpoint profile %q not found", name)
+	}
+	delete(cfg.Profiles, name)
+	if cfg.Current == name {
+		cfg.Current = ""
+	}
+	return nil
+}
+
+// profileNames returns the sorted names of the profiles, only the custom ones
+// when custom is set.
+func profileNames(cfg dennis.EndpointConfig, custom bool) []string {
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		if _, ok := dennis.DefaultProfiles[name]; custom && ok {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,115 @@
+package profile
+
+import (
+	"reflect"
+	"testing"
+
+	"dennis"
+)
+
+func newConfig() dennis.EndpointConfig {
+	return dennis.EndpointConfig{
+		Current: "stg",
+		Profiles: map[string]string{
+			"dev":   dennis.DefaultProfiles["dev"],
+			"local": dennis.DefaultProfiles["local"],
+			"stg":   "https://dennis.stg.example.com",
+		},
+	}
+}
+
+func TestAdd(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile string
+		host    string
+		want    string
+		wantErr bool
+	}{
+		{name: "new", profile: "prod", host: "https://dennis.example.com/", want: "https://dennis.example.com"},
+		{name: "replace", profile: "stg", host: "https://stg.example.com", want: "https://stg.example.com"},
+		{name: "empty", profile: "", host: "https://dennis.example.com", wantErr: true},
+		{name: "blank", profile: "  ", host: "https://dennis.example.com", wantErr: true},
+		{name: "built-in", profile: "local", host: "http://localhost:9999", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newConfig()
+			err := add(&cfg, tt.profile, tt.host)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("add() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !reflect.DeepEqual(cfg, newConfig()) {
+					t.Errorf("add() changed the config to %+v", cfg)
+				}
+				return
+			}
+			if got := cfg.Profiles[tt.profile]; got != tt.want {
+				t.Errorf("add() host = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSwitchTo(t *testing.T) {
+	tests := []struct {
+		profile string
+		wantErr bool
+	}{
+		{profile: "dev"},
+		{profile: "stg"},
+		{profile: "prod", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.profile, func(t *testing.T) {
+			cfg := newConfig()
+			err := switchTo(&cfg, tt.profile)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("switchTo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if want := map[bool]string{false: tt.profile, true: "stg"}[tt.wantErr]; cfg.Current != want {
+				t.Errorf("switchTo() current = %s, want %s", cfg.Current, want)
+			}
+		})
+	}
+}
+
+func TestRemove(t *testing.T) {
+	tests := []struct {
+		name       string
+		profile    string
+		wantNames  []string
+		wantActive string
+		wantErr    bool
+	}{
+		{name: "active", profile: "stg", wantNames: []string{"dev", "local"}, wantActive: dennis.DefaultProfile},
+		{name: "built-in", profile: "dev", wantNames: []string{"dev", "local", "stg"}, wantActive: "stg", wantErr: true},
+		{name: "unknown", profile: "prod", wantNames: []string{"dev", "local", "stg"}, wantActive: "stg", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newConfig()
+			err := remove(&cfg, tt.profile)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("remove() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := profileNames(cfg, false); !reflect.DeepEqual(got, tt.wantNames) {
+				t.Errorf("remove() profiles = %v, want %v", got, tt.wantNames)
+			}
+			if got := cfg.Active(); got != tt.wantActive {
+				t.Errorf("remove() active = %s, want %s", got, tt.wantActive)
+			}
+		})
+	}
+}
+
+func TestProfileNames(t *testing.T) {
+	cfg := newConfig()
+	if got, want := profileNames(cfg, false), []string{"dev", "local", "stg"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("profileNames() = %v, want %v", got, want)
+	}
+	if got, want := profileNames(cfg, true), []string{"stg"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("profileNames(custom) = %v, want %v", got, want)
+	}
+}